@@ -0,0 +1,16 @@
+// Package assets embeds the registry data/ directory.
+//
+// By default everything under data/ is embedded. Build tags trim the embed
+// for binaries that do not need all of it:
+//
+//	registry_nogenesis  omit data/genesis (network and chain configs only)
+//	registry_nodev      omit devnet networks (environment = "devnet") and
+//	                    their genesis files
+//
+// The tags can be combined. Every trimmed build embeds only what the registry
+// package reads (data/networks and, without registry_nogenesis, the genesis
+// files); the generated chainList and eip3085 files, which list devnet
+// chains too, are in the full build only. GenesisEmbedded,
+// DevNetworksEmbedded and OmittedNetworks report what the current build
+// contains. The registry_nodev files are generated by make generate.
+package assets
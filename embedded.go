@@ -1,3 +1,5 @@
+//go:build !registry_nogenesis && !registry_nodev
+
 package assets
 
 import "embed"
@@ -6,3 +8,12 @@ import "embed"
 //
 //go:embed data/**
 var FS embed.FS
+
+// What this build embeds; see the registry_nogenesis and registry_nodev build tags.
+const (
+	GenesisEmbedded     = true
+	DevNetworksEmbedded = true
+)
+
+// OmittedNetworks lists the devnet networks left out of this build.
+var OmittedNetworks []string
@@ -0,0 +1,19 @@
+//go:build registry_nogenesis && !registry_nodev
+
+package assets
+
+import "embed"
+
+// FS contains the embedded network configs, without data/genesis.
+//
+//go:embed data/networks
+var FS embed.FS
+
+// What this build embeds; see the registry_nogenesis and registry_nodev build tags.
+const (
+	GenesisEmbedded     = false
+	DevNetworksEmbedded = true
+)
+
+// OmittedNetworks lists the devnet networks left out of this build.
+var OmittedNetworks []string
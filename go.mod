@@ -2,4 +2,7 @@ module github.com/compose-network/registry
 
 go 1.23
 
-require github.com/BurntSushi/toml v1.5.0
+require (
+	github.com/BurntSushi/toml v1.5.0
+	github.com/klauspost/compress v1.18.0
+)
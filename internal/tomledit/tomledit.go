@@ -0,0 +1,93 @@
+// Package tomledit reads and sets single values in TOML text line by line,
+// so edits keep the comments and layout of hand-maintained files. It
+// understands top-level keys and [table] headers, not arrays of tables or
+// multi-line values.
+package tomledit
+
+import "strings"
+
+// Get returns the raw value text of key in [table] ("" is the top level),
+// without any trailing comment.
+func Get(text, table, key string) (string, bool) {
+	section := ""
+	for _, line := range strings.Split(text, "\n") {
+		l, _ := splitComment(strings.TrimSpace(line))
+		if strings.HasPrefix(l, "[") {
+			section = strings.Trim(l, "[] ")
+			continue
+		}
+		if section != table {
+			continue
+		}
+		if k, v, ok := strings.Cut(l, "="); ok && strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v), true
+		}
+	}
+	return "", false
+}
+
+// Set sets key in [table] to the raw value text and reports whether text
+// changed. A trailing comment on a replaced line is kept. Missing keys go
+// at the end of their table; missing tables are appended.
+func Set(text, table, key, value string) (string, bool) {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	line := key + " = " + value
+	section, start, end := "", 0, -1
+	for i, l := range lines {
+		t, comment := splitComment(strings.TrimSpace(l))
+		if strings.HasPrefix(t, "[") {
+			if section == table && end < 0 {
+				end = i
+			}
+			section = strings.Trim(t, "[] ")
+			if section == table {
+				start = i + 1
+			}
+			continue
+		}
+		if section != table {
+			continue
+		}
+		if k, _, ok := strings.Cut(t, "="); ok && strings.TrimSpace(k) == key {
+			if lines[i] == line+comment {
+				return text, false
+			}
+			lines[i] = line + comment
+			return strings.Join(lines, "\n") + "\n", true
+		}
+	}
+	if section == table && end < 0 {
+		end = len(lines)
+	}
+	if end < 0 {
+		lines = append(lines, "", "["+table+"]", line)
+		return strings.Join(lines, "\n") + "\n", true
+	}
+	// insert after the last non-blank line of the table
+	at := end
+	for at > start && strings.TrimSpace(lines[at-1]) == "" {
+		at--
+	}
+	lines = append(lines[:at], append([]string{line}, lines[at:]...)...)
+	return strings.Join(lines, "\n") + "\n", true
+}
+
+// splitComment splits a TOML line at a # outside quoted strings into the
+// code and the comment, the latter with the whitespace before the #.
+func splitComment(l string) (string, string) {
+	var quote byte
+	for i := 0; i < len(l); i++ {
+		switch c := l[i]; {
+		case quote == 0 && c == '#':
+			code := strings.TrimRight(l[:i], " \t")
+			return code, l[len(code):]
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == '"' && c == '\\':
+			i++ // skip the escaped character
+		case c == quote:
+			quote = 0
+		}
+	}
+	return l, ""
+}
@@ -0,0 +1,72 @@
+package tomledit
+
+import (
+	"strings"
+	"testing"
+)
+
+const sample = `name = "rollup-a" # display only
+chain_id = 0
+
+[addresses]
+Mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+
+[genesis] # filled by genesis-gen
+l2_time = 1756804404
+`
+
+func TestGet(t *testing.T) {
+	cases := []struct {
+		name, text, table, key string
+		want                   string
+		ok                     bool
+	}{
+		{"top level", sample, "", "chain_id", "0", true},
+		{"inline comment", sample, "", "name", `"rollup-a"`, true},
+		{"table", sample, "addresses", "Mailbox", `"0x248721a59a2756E579026aDA017bd9B6adFe3e57"`, true},
+		{"table header comment", sample, "genesis", "l2_time", "1756804404", true},
+		{"key in another table", sample, "", "l2_time", "", false},
+		{"missing table", sample, "sequencer", "host", "", false},
+		{"# inside string", "name = \"a # b\" # note\n", "", "name", `"a # b"`, true},
+		{"escaped quote", "name = \"say \\\"#1\\\"\" # note\n", "", "name", `"say \"#1\""`, true},
+		{"literal string", "name = 'C:\\ # x' # note\n", "", "name", `'C:\ # x'`, true},
+		{"commented out", "# chain_id = 5\n", "", "chain_id", "", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Get(tc.text, tc.table, tc.key)
+			if got != tc.want || ok != tc.ok {
+				t.Fatalf("Get(%q, %q) = %q, %t, want %q, %t", tc.table, tc.key, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	cases := []struct {
+		name, table, key, value string
+		want                    string // "" when unchanged
+	}{
+		{"replace keeps comment", "", "name", `"Rollup A"`, strings.Replace(sample, `name = "rollup-a"`, `name = "Rollup A"`, 1)},
+		{"unchanged", "", "chain_id", "0", ""},
+		{"append to table", "addresses", "Bridge", `"0x54e692F4e290409035a9cC6A3d55eB047c82112C"`,
+			strings.Replace(sample, "3e57\"\n", "3e57\"\nBridge = \"0x54e692F4e290409035a9cC6A3d55eB047c82112C\"\n", 1)},
+		{"append to last table", "genesis", "file", `"sha256:00"`, sample + "file = \"sha256:00\"\n"},
+		{"missing table", "sequencer", "port", "9898", sample + "\n[sequencer]\nport = 9898\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, changed := Set(sample, tc.table, tc.key, tc.value)
+			want := tc.want
+			if want == "" {
+				want = sample
+			}
+			if got != want || changed != (tc.want != "") {
+				t.Fatalf("Set(%q, %q, %s) = %t\n%s\nwant %t\n%s", tc.table, tc.key, tc.value, changed, got, tc.want != "", want)
+			}
+			if v, _ := Get(got, tc.table, tc.key); v != tc.value {
+				t.Fatalf("Get after Set = %q, want %q", v, tc.value)
+			}
+		})
+	}
+}
@@ -12,10 +12,17 @@ type ChainListEntry struct {
 	Explorers            []string             `toml:"explorers" json:"explorers"`
 	DataAvailabilityType string               `toml:"data_availability_type" json:"dataAvailabilityType"`
 	Parent               ChainListEntryParent `toml:"parent" json:"parent"`
+	NativeCurrency       *NativeCurrency      `toml:"native_currency,omitempty" json:"nativeCurrency,omitempty"`
 	GasPayingToken       string               `toml:"gas_paying_token,omitempty" json:"gasPayingToken,omitempty"`
 	FaultProofs          *FaultProofs         `toml:"fault_proofs,omitempty" json:"faultProofs,omitempty"`
 }
 
+type NativeCurrency struct {
+	Name     string `toml:"name" json:"name"`
+	Symbol   string `toml:"symbol" json:"symbol"`
+	Decimals uint8  `toml:"decimals" json:"decimals"`
+}
+
 type ChainListEntryParent struct {
 	Type  string `toml:"type" json:"type"`
 	Chain string `toml:"chain" json:"chain"`
@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"strings"
+)
+
+// Address is a 0x-prefixed 20-byte hex address as written in the TOML
+// files. Use Kind to tell real deployments from unset, zero and
+// placeholder values.
+type Address string
+
+// AddressKind classifies an Address.
+type AddressKind int
+
+const (
+	// AddressUnset is an empty value: the contract is not deployed (yet).
+	AddressUnset AddressKind = iota
+	// AddressInvalid is not a 0x-prefixed 20-byte hex string.
+	AddressInvalid
+	// AddressZero is 0x0000000000000000000000000000000000000000.
+	AddressZero
+	// AddressPlaceholder is a low dummy value such as 0x…0001 (at most
+	// 0xffff), used to fill a field before the contract exists.
+	AddressPlaceholder
+	// AddressReal is any other well-formed address.
+	AddressReal
+)
+
+// placeholderMaxDigits is the number of trailing hex digits a placeholder
+// may use: values up to 0xffff.
+const placeholderMaxDigits = 4
+
+func (k AddressKind) String() string {
+	switch k {
+	case AddressUnset:
+		return "unset"
+	case AddressInvalid:
+		return "invalid"
+	case AddressZero:
+		return "zero"
+	case AddressPlaceholder:
+		return "placeholder"
+	case AddressReal:
+		return "real"
+	}
+	return "unknown"
+}
+
+// Kind classifies a.
+func (a Address) Kind() AddressKind {
+	s := strings.TrimSpace(string(a))
+	switch {
+	case s == "":
+		return AddressUnset
+	case !isHexAddress(s):
+		return AddressInvalid
+	}
+	digits := strings.TrimLeft(s[2:], "0")
+	switch {
+	case digits == "":
+		return AddressZero
+	case len(digits) <= placeholderMaxDigits:
+		return AddressPlaceholder
+	}
+	return AddressReal
+}
+
+// IsReal reports whether a looks like a deployed contract address.
+func (a Address) IsReal() bool { return a.Kind() == AddressReal }
+
+// IsZero reports whether a is the zero address.
+func (a Address) IsZero() bool { return a.Kind() == AddressZero }
+
+// IsPlaceholder reports whether a is a low dummy value such as 0x…0001.
+func (a Address) IsPlaceholder() bool { return a.Kind() == AddressPlaceholder }
+
+// String returns the address as written.
+func (a Address) String() string { return string(a) }
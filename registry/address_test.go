@@ -0,0 +1,53 @@
+package registry
+
+import "testing"
+
+func TestAddress_Kind(t *testing.T) {
+	cases := []struct {
+		addr Address
+		want AddressKind
+	}{
+		{"", AddressUnset},
+		{"  ", AddressUnset},
+		{"0x1234", AddressInvalid},
+		{"248721a59a2756E579026aDA017bd9B6adFe3e57", AddressInvalid},
+		{"0x0000000000000000000000000000000000000000", AddressZero},
+		{"0x0000000000000000000000000000000000000001", AddressPlaceholder},
+		{"0x000000000000000000000000000000000000fFfF", AddressPlaceholder},
+		{"0x0000000000000000000000000000000000010000", AddressReal},
+		{"0x248721a59a2756E579026aDA017bd9B6adFe3e57", AddressReal},
+	}
+	for _, tc := range cases {
+		if got := tc.addr.Kind(); got != tc.want {
+			t.Errorf("Address(%q).Kind() = %s, want %s", tc.addr, got, tc.want)
+		}
+	}
+}
+
+func TestAddress_DataNetworks(t *testing.T) {
+	n, err := dataRegistry(t).GetNetworkBySlug("sepolia-dev")
+	if err != nil {
+		t.Fatalf("GetNetworkBySlug() error: %v", err)
+	}
+	cfg, err := n.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if !cfg.Publisher.SuperblockContract.IsPlaceholder() {
+		t.Errorf("superblock_contract %s: Kind() = %s, want placeholder", cfg.Publisher.SuperblockContract, cfg.Publisher.SuperblockContract.Kind())
+	}
+	if !cfg.Publisher.DisputeGameFactory.IsZero() {
+		t.Errorf("dispute_game_factory %s: Kind() = %s, want zero", cfg.Publisher.DisputeGameFactory, cfg.Publisher.DisputeGameFactory.Kind())
+	}
+	c, err := n.GetChainBySlug("rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainBySlug() error: %v", err)
+	}
+	ccfg, err := c.LoadConfig()
+	if err != nil {
+		t.Fatalf("chain LoadConfig() error: %v", err)
+	}
+	if !ccfg.Addresses.Mailbox.IsReal() {
+		t.Errorf("Mailbox %s: Kind() = %s, want real", ccfg.Addresses.Mailbox, ccfg.Addresses.Mailbox.Kind())
+	}
+}
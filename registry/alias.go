@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Alias returns the slug n was looked up by when it was resolved through
+// an alias in compose.toml, or "" when it was looked up by its own slug.
+func (n Network) Alias() string { return n.alias }
+
+// Alias returns the identifier c was looked up by when a network or chain
+// alias was used (e.g. "hoodi/rollup-old" for "hoodi/rollup-a"), or "".
+func (c Chain) Alias() string { return c.alias }
+
+// networkByAlias returns the slug of the network whose compose.toml lists
+// alias in "aliases", or "" if none does. An alias listed by several
+// networks is an error.
+func (r Registry) networkByAlias(alias string) (string, error) {
+	idx := r.aliasIndex()
+	if idx == nil {
+		m, err := r.networkAliases()
+		if err != nil {
+			return "", err
+		}
+		return m.owner(alias, "network")
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if idx.networks == nil {
+		m, err := r.networkAliases()
+		if err != nil {
+			return "", err
+		}
+		idx.networks = m
+	}
+	return idx.networks.owner(alias, "network")
+}
+
+// chainByAlias returns the slug of the chain in n whose TOML lists alias in
+// "aliases", or "" if none does. An alias listed by several chains is an
+// error.
+func (n Network) chainByAlias(alias string) (string, error) {
+	idx := n.r.aliasIndex()
+	if idx == nil {
+		m, err := n.chainAliases()
+		if err != nil {
+			return "", err
+		}
+		return m.owner(alias, n.slug+" chain")
+	}
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	m, ok := idx.chains[n.slug]
+	if !ok {
+		var err error
+		if m, err = n.chainAliases(); err != nil {
+			return "", err
+		}
+		if idx.chains == nil {
+			idx.chains = make(map[string]aliasOwners)
+		}
+		idx.chains[n.slug] = m
+	}
+	return m.owner(alias, n.slug+" chain")
+}
+
+// aliasIndex memoizes alias scans for registries built WithIndexCache, whose
+// data does not change. Other registries rescan on every alias lookup.
+type aliasIndex struct {
+	mu       sync.Mutex
+	networks aliasOwners            // nil until scanned
+	chains   map[string]aliasOwners // by network slug
+}
+
+func (r Registry) aliasIndex() *aliasIndex {
+	if r.settings == nil {
+		return nil
+	}
+	return r.settings.aliases
+}
+
+// aliasOwners maps an alias to the slugs that list it, in directory order.
+type aliasOwners map[string][]string
+
+// owner returns the single slug listing alias, "" if none does, or an error
+// naming every kind entry that lists it.
+func (m aliasOwners) owner(alias, kind string) (string, error) {
+	switch owners := m[alias]; len(owners) {
+	case 0:
+		return "", nil
+	case 1:
+		return owners[0], nil
+	default:
+		return "", fmt.Errorf("alias %q is listed by %s %s", alias, kind, strings.Join(owners, ", "))
+	}
+}
+
+// networkAliases maps every network alias to the networks listing it.
+// Files that do not decode are skipped: they fail on their own lookups and
+// in Validate, not on the lookup of an unrelated alias.
+func (r Registry) networkAliases() (aliasOwners, error) {
+	entries, err := fs.ReadDir(r.fs, "networks")
+	if err != nil {
+		return nil, fmt.Errorf("list networks: %w", err)
+	}
+	out := aliasOwners{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		var v struct {
+			Aliases []string `toml:"aliases"`
+		}
+		if err := (Network{slug: e.Name(), r: r}).Decode(&v); err != nil {
+			continue
+		}
+		for _, a := range v.Aliases {
+			if !slices.Contains(out[a], e.Name()) {
+				out[a] = append(out[a], e.Name())
+			}
+		}
+	}
+	return out, nil
+}
+
+// chainAliases maps every chain alias in n to the chains listing it,
+// skipping files that do not decode as networkAliases does.
+func (n Network) chainAliases() (aliasOwners, error) {
+	chains, err := n.ListChains()
+	if err != nil {
+		return nil, err
+	}
+	out := aliasOwners{}
+	for _, c := range chains {
+		var v struct {
+			Aliases []string `toml:"aliases"`
+		}
+		if err := c.Decode(&v); err != nil {
+			continue
+		}
+		for _, a := range v.Aliases {
+			if !slices.Contains(out[a], c.slug) {
+				out[a] = append(out[a], c.slug)
+			}
+		}
+	}
+	return out, nil
+}
+
+// requested returns the slug n was looked up by.
+func (n Network) requested() string {
+	if n.alias != "" {
+		return n.alias
+	}
+	return n.slug
+}
+
+// chainExists reports whether networks/<network>/<slug>.toml exists.
+func (n Network) chainExists(slug string) bool {
+	_, err := fs.Stat(n.r.fs, path.Join("networks", n.slug, slug+".toml"))
+	return err == nil
+}
@@ -0,0 +1,115 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func aliasRegistry(t *testing.T) Registry {
+	t.Helper()
+	r, err := NewFromFS(fstest.MapFS{
+		"networks/hoodi/compose.toml":  {Data: []byte("aliases = [\"hoodi-old\"]\n[l1]\nchain_id = 560048\n")},
+		"networks/hoodi/rollup-a.toml": {Data: []byte("chain_id = 11113\naliases = [\"rollup-old\"]\n")},
+		"networks/hoodi/rollup-b.toml": {Data: []byte("chain_id = 22224\n")},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	return r
+}
+
+func TestGetNetworkBySlug_Alias(t *testing.T) {
+	r := aliasRegistry(t)
+	n, err := r.GetNetworkBySlug("hoodi-old")
+	if err != nil {
+		t.Fatalf("GetNetworkBySlug(hoodi-old) error: %v", err)
+	}
+	if n.Slug() != "hoodi" || n.Alias() != "hoodi-old" {
+		t.Fatalf("got slug %q alias %q, want hoodi via hoodi-old", n.Slug(), n.Alias())
+	}
+	if n, _ := r.GetNetworkBySlug("hoodi"); n.Alias() != "" {
+		t.Fatalf("canonical lookup alias = %q, want empty", n.Alias())
+	}
+	if _, err := r.GetNetworkBySlug("nope"); !errors.Is(err, ErrNetworkNotFound) {
+		t.Fatalf("GetNetworkBySlug(nope) error = %v, want ErrNetworkNotFound", err)
+	}
+}
+
+func TestGetChainByIdentifier_Alias(t *testing.T) {
+	r := aliasRegistry(t)
+	cases := []struct {
+		in, alias string
+	}{
+		{"hoodi/rollup-a", ""},
+		{"hoodi/rollup-old", "hoodi/rollup-old"},
+		{"hoodi-old/rollup-a", "hoodi-old/rollup-a"},
+		{"hoodi-old/rollup-old", "hoodi-old/rollup-old"},
+	}
+	for _, tc := range cases {
+		c, err := r.GetChainByIdentifier(tc.in)
+		if err != nil {
+			t.Fatalf("GetChainByIdentifier(%s) error: %v", tc.in, err)
+		}
+		if c.Identifier() != "hoodi/rollup-a" || c.Alias() != tc.alias {
+			t.Fatalf("GetChainByIdentifier(%s) = %s alias %q, want hoodi/rollup-a alias %q", tc.in, c.Identifier(), c.Alias(), tc.alias)
+		}
+		if cfg, err := c.LoadConfig(); err != nil || cfg.ChainID != 11113 {
+			t.Fatalf("LoadConfig via %s = %d, %v", tc.in, cfg.ChainID, err)
+		}
+	}
+	if _, err := r.GetChainByIdentifier("hoodi/rollup-c"); !errors.Is(err, ErrChainNotFound) {
+		t.Fatalf("GetChainByIdentifier(hoodi/rollup-c) error = %v, want ErrChainNotFound", err)
+	}
+}
+
+func TestAliasLookup_SkipsBrokenFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/hoodi/compose.toml":  {Data: []byte("aliases = [\"hoodi-old\"]\n")},
+		"networks/hoodi/rollup-a.toml": {Data: []byte("chain_id = 11113\naliases = [\"rollup-old\"]\n")},
+		"networks/hoodi/broken.toml":   {Data: []byte("chain_id = \n")},
+		"networks/other/compose.toml":  {Data: []byte("[l1\n")},
+	}
+	for _, opts := range [][]Option{nil, {WithIndexCache()}} {
+		r, err := NewFromFS(fsys, opts...)
+		if err != nil {
+			t.Fatalf("NewFromFS error: %v", err)
+		}
+		for i := 0; i < 2; i++ { // the second round is served from the index when cached
+			if n, err := r.GetNetworkBySlug("hoodi-old"); err != nil || n.Slug() != "hoodi" {
+				t.Fatalf("GetNetworkBySlug(hoodi-old) = %v, %v", n.Slug(), err)
+			}
+			if _, err := r.GetNetworkBySlug("nope"); !errors.Is(err, ErrNetworkNotFound) {
+				t.Fatalf("GetNetworkBySlug(nope) error = %v, want ErrNetworkNotFound", err)
+			}
+			if c, err := r.GetChainByIdentifier("hoodi/rollup-old"); err != nil || c.Slug() != "rollup-a" {
+				t.Fatalf("GetChainByIdentifier(hoodi/rollup-old) = %v, %v", c.Slug(), err)
+			}
+			if _, err := r.GetChainByIdentifier("hoodi/nope"); !errors.Is(err, ErrChainNotFound) {
+				t.Fatalf("GetChainByIdentifier(hoodi/nope) error = %v, want ErrChainNotFound", err)
+			}
+		}
+	}
+}
+
+func TestAliasLookup_Duplicates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/hoodi/compose.toml":  {Data: []byte("aliases = [\"old\"]\n")},
+		"networks/dev/compose.toml":    {Data: []byte("aliases = [\"old\"]\n")},
+		"networks/hoodi/rollup-a.toml": {Data: []byte("chain_id = 11113\naliases = [\"rollup-old\"]\n")},
+		"networks/hoodi/rollup-b.toml": {Data: []byte("chain_id = 22224\naliases = [\"rollup-old\"]\n")},
+	}
+	for _, opts := range [][]Option{nil, {WithIndexCache()}} {
+		r, err := NewFromFS(fsys, opts...)
+		if err != nil {
+			t.Fatalf("NewFromFS error: %v", err)
+		}
+		if _, err := r.GetNetworkBySlug("old"); err == nil || !strings.Contains(err.Error(), `alias "old" is listed by network dev, hoodi`) {
+			t.Fatalf("GetNetworkBySlug(old) error = %v, want ambiguous alias", err)
+		}
+		if _, err := r.GetChainByIdentifier("hoodi/rollup-old"); err == nil || !strings.Contains(err.Error(), `alias "rollup-old" is listed by hoodi chain rollup-a, rollup-b`) {
+			t.Fatalf("GetChainByIdentifier(hoodi/rollup-old) error = %v, want ambiguous alias", err)
+		}
+	}
+}
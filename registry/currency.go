@@ -0,0 +1,48 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IsCustomGasToken reports whether the chain pays gas in an L1 ERC-20
+// rather than ETH.
+func (nc NativeCurrency) IsCustomGasToken() bool { return nc.L1Token.Kind() != AddressUnset }
+
+// Validate checks the [native_currency] table. Symbols follow EIP-3085
+// (2-6 characters); decimals must be 18, as both EIP-3085 wallets and OP
+// Stack custom gas tokens assume.
+func (nc NativeCurrency) Validate() error {
+	if strings.TrimSpace(nc.Name) == "" {
+		return errors.New("native_currency.name required")
+	}
+	if n := len(strings.TrimSpace(nc.Symbol)); n < 2 || n > 6 {
+		return fmt.Errorf("native_currency.symbol %q must be 2-6 characters", nc.Symbol)
+	}
+	if nc.Decimals != 18 {
+		return fmt.Errorf("native_currency.decimals = %d, must be 18", nc.Decimals)
+	}
+	switch nc.L1Token.Kind() {
+	case AddressInvalid:
+		return fmt.Errorf("native_currency.l1_token %q is not a 0x-prefixed 20-byte address", nc.L1Token)
+	case AddressZero:
+		return errors.New("native_currency.l1_token must not be the zero address")
+	case AddressPlaceholder:
+		return fmt.Errorf("native_currency.l1_token %s is a placeholder address", nc.L1Token)
+	}
+	return nil
+}
+
+// isHexAddress reports whether s is "0x" followed by 40 hex digits.
+func isHexAddress(s string) bool {
+	if len(s) != 42 || (s[:2] != "0x" && s[:2] != "0X") {
+		return false
+	}
+	for _, c := range s[2:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package registry
+
+import "testing"
+
+func TestNativeCurrency_Validate(t *testing.T) {
+	eth := NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+	token := Address("0x1111111111111111111111111111111111111111")
+	cases := []struct {
+		name string
+		nc   NativeCurrency
+		ok   bool
+	}{
+		{"eth", eth, true},
+		{"custom gas token", NativeCurrency{Name: "Compose", Symbol: "CMP", Decimals: 18, L1Token: token}, true},
+		{"missing name", NativeCurrency{Symbol: "ETH", Decimals: 18}, false},
+		{"short symbol", NativeCurrency{Name: "Ether", Symbol: "E", Decimals: 18}, false},
+		{"long symbol", NativeCurrency{Name: "Ether", Symbol: "ETHERSS", Decimals: 18}, false},
+		{"decimals", NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 6}, false},
+		{"bad token", NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18, L1Token: "0x1234"}, false},
+		{"placeholder token", NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18, L1Token: "0x0000000000000000000000000000000000000001"}, false},
+		{"zero token", NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18, L1Token: "0x0000000000000000000000000000000000000000"}, false},
+	}
+	for _, tc := range cases {
+		if err := tc.nc.Validate(); (err == nil) != tc.ok {
+			t.Errorf("%s: Validate() = %v, want ok=%v", tc.name, err, tc.ok)
+		}
+	}
+	if eth.IsCustomGasToken() {
+		t.Errorf("ETH reported as custom gas token")
+	}
+}
+
+func TestChains_NativeCurrency(t *testing.T) {
+	chains, err := New().ListChains()
+	if err != nil {
+		t.Fatalf("ListChains error: %v", err)
+	}
+	for _, c := range chains {
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) error: %v", c.Identifier(), err)
+		}
+		if err := cfg.NativeCurrency.Validate(); err != nil {
+			t.Errorf("%s: %v", c.Identifier(), err)
+		}
+	}
+}
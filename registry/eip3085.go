@@ -0,0 +1,44 @@
+package registry
+
+import (
+	"strconv"
+	"strings"
+)
+
+// AddEthereumChainParameter is the EIP-3085 wallet_addEthereumChain
+// parameter object. Marshal it to JSON and pass it as the single param.
+type AddEthereumChainParameter struct {
+	ChainID           string           `json:"chainId"`
+	ChainName         string           `json:"chainName"`
+	NativeCurrency    *EIP3085Currency `json:"nativeCurrency,omitempty"`
+	RPCURLs           []string         `json:"rpcUrls"`
+	BlockExplorerURLs []string         `json:"blockExplorerUrls,omitempty"`
+}
+
+// EIP3085Currency is the nativeCurrency member of AddEthereumChainParameter.
+type EIP3085Currency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// EIP3085 returns the wallet_addEthereumChain parameter for this chain.
+// NativeCurrency is omitted only for a chain that lacks the required
+// [native_currency] table and so fails Registry.Validate.
+func (cfg ChainConfig) EIP3085() AddEthereumChainParameter {
+	p := AddEthereumChainParameter{
+		ChainID:   "0x" + strconv.FormatUint(cfg.ChainID, 16),
+		ChainName: cfg.Name,
+		RPCURLs:   []string{},
+	}
+	if nc := cfg.NativeCurrency; nc != (NativeCurrency{}) {
+		p.NativeCurrency = &EIP3085Currency{Name: nc.Name, Symbol: nc.Symbol, Decimals: nc.Decimals}
+	}
+	if s := strings.TrimSpace(cfg.PublicRPC); s != "" {
+		p.RPCURLs = []string{s}
+	}
+	if s := strings.TrimSpace(cfg.Explorer); s != "" {
+		p.BlockExplorerURLs = []string{s}
+	}
+	return p
+}
@@ -0,0 +1,39 @@
+package registry
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChainConfig_EIP3085(t *testing.T) {
+	r := New()
+	c, err := r.GetChainByIdentifier("hoodi/rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier error: %v", err)
+	}
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	b, err := json.Marshal(cfg.EIP3085())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	want := `{"chainId":"0x2b69","chainName":"rollup-a",` +
+		`"nativeCurrency":{"name":"Ether","symbol":"ETH","decimals":18},` +
+		`"rpcUrls":["https://rpc-a.testnet.compose.network"],` +
+		`"blockExplorerUrls":["https://rollup-a.explorer.compose.network"]}`
+	if string(b) != want {
+		t.Fatalf("EIP3085 = %s\nwant %s", b, want)
+	}
+}
+
+func TestChainConfig_EIP3085_Minimal(t *testing.T) {
+	b, err := json.Marshal(ChainConfig{ChainID: 255}.EIP3085())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if want := `{"chainId":"0xff","chainName":"","rpcUrls":[]}`; string(b) != want {
+		t.Fatalf("EIP3085 = %s, want %s", b, want)
+	}
+}
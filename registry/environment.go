@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+)
+
+// ErrDevnetForbidden is returned by lookups of devnet networks when the
+// registry guards against them (WithDevnetGuard or the registry_production
+// build tag).
+var ErrDevnetForbidden = errors.New("devnet network not allowed")
+
+// Environment is the deployment tier of a network, set by "environment" in
+// compose.toml.
+type Environment string
+
+const (
+	EnvMainnet Environment = "mainnet"
+	EnvTestnet Environment = "testnet"
+	EnvDevnet  Environment = "devnet"
+)
+
+// Valid reports whether e is one of the known environments.
+func (e Environment) Valid() bool {
+	switch e {
+	case EnvMainnet, EnvTestnet, EnvDevnet:
+		return true
+	}
+	return false
+}
+
+// WithDevnetGuard hides devnet networks: listings skip them and lookups
+// return ErrDevnetForbidden. The embedded registry of a binary built with
+// the registry_production tag has the guard on; New applies it, NewFromDir
+// and NewFromFS do not. Environments are read once, on the first guarded
+// lookup.
+func WithDevnetGuard() Option {
+	return func(o *options) { o.guard = true }
+}
+
+// Environment returns the network's "environment" field, which is the only
+// source of the classification; it is "" when unset.
+func (n Network) Environment() (Environment, error) {
+	cfg, err := n.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return cfg.Environment, nil
+}
+
+// ListNetworksByEnvironment lists the networks whose environment is env.
+func (r Registry) ListNetworksByEnvironment(env Environment) ([]Network, error) {
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+	var out []Network
+	for _, n := range nets {
+		e, err := n.Environment()
+		if err != nil {
+			return nil, err
+		}
+		if e == env {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+// devnetGuard holds the devnet slugs of a guarded registry, loaded once.
+type devnetGuard struct {
+	once    sync.Once
+	devnets map[string]bool
+	err     error
+}
+
+// checkGuard returns ErrDevnetForbidden for a devnet network when r guards
+// against them.
+func (r Registry) checkGuard(slug string) error {
+	if r.settings == nil || r.settings.guard == nil {
+		return nil
+	}
+	g := r.settings.guard
+	g.once.Do(func() { g.devnets, g.err = r.devnets() })
+	if g.err != nil {
+		return g.err
+	}
+	if g.devnets[slug] {
+		return fmt.Errorf("%w: %s", ErrDevnetForbidden, slug)
+	}
+	return nil
+}
+
+// devnets returns the slugs of the networks whose environment is devnet.
+func (r Registry) devnets() (map[string]bool, error) {
+	entries, err := fs.ReadDir(r.fs, "networks")
+	if err != nil {
+		return nil, fmt.Errorf("list networks: %w", err)
+	}
+	out := map[string]bool{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		env, err := Network{slug: e.Name(), r: r}.Environment()
+		if err != nil {
+			return nil, err
+		}
+		if env == EnvDevnet {
+			out[e.Name()] = true
+		}
+	}
+	return out, nil
+}
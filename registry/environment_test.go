@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestListNetworksByEnvironment(t *testing.T) {
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	cases := map[Environment][]string{
+		EnvTestnet: {"hoodi"},
+		EnvDevnet:  {"hoodi-dev", "sepolia-dev"},
+		EnvMainnet: nil,
+	}
+	for env, want := range cases {
+		nets, err := r.ListNetworksByEnvironment(env)
+		if err != nil {
+			t.Fatalf("ListNetworksByEnvironment(%s) error: %v", env, err)
+		}
+		var got []string
+		for _, n := range nets {
+			got = append(got, n.Slug())
+		}
+		if len(got) != len(want) {
+			t.Fatalf("ListNetworksByEnvironment(%s) = %v, want %v", env, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("ListNetworksByEnvironment(%s) = %v, want %v", env, got, want)
+			}
+		}
+	}
+}
+
+func TestNetworkEnvironment_Unset(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/old-dev/compose.toml": {Data: []byte(`name = "old-dev"`)},
+	}
+	r, err := NewFromFS(fsys)
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	n, err := r.GetNetworkBySlug("old-dev")
+	if err != nil {
+		t.Fatalf("GetNetworkBySlug error: %v", err)
+	}
+	if env, err := n.Environment(); err != nil || env != "" {
+		t.Fatalf("Environment() = %q, %v; want unset (the slug does not classify)", env, err)
+	}
+	g, err := NewFromFS(fsys, WithDevnetGuard())
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	if _, err := g.GetNetworkBySlug("old-dev"); err != nil {
+		t.Fatalf("guarded GetNetworkBySlug(old-dev) error: %v", err)
+	}
+}
+
+// TestDevnetGuard also runs with -tags registry_production (see Makefile),
+// where New has the guard on.
+func TestDevnetGuard(t *testing.T) {
+	if _, err := New().GetNetworkBySlug("hoodi-dev"); productionBuild != errors.Is(err, ErrDevnetForbidden) {
+		t.Fatalf("New().GetNetworkBySlug(hoodi-dev) error = %v, production build = %v", err, productionBuild)
+	}
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	if _, err := r.GetNetworkBySlug("hoodi-dev"); err != nil {
+		t.Fatalf("NewFromDir is unguarded by default, GetNetworkBySlug(hoodi-dev) error: %v", err)
+	}
+
+	g, err := NewFromDir("../data", WithDevnetGuard())
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	if _, err := g.GetNetworkBySlug("hoodi-dev"); !errors.Is(err, ErrDevnetForbidden) {
+		t.Fatalf("GetNetworkBySlug(hoodi-dev) = %v, want ErrDevnetForbidden", err)
+	}
+	if _, err := g.GetChainByIdentifier("sepolia-dev/rollup-a"); !errors.Is(err, ErrDevnetForbidden) {
+		t.Fatalf("GetChainByIdentifier(sepolia-dev/rollup-a) = %v, want ErrDevnetForbidden", err)
+	}
+	if _, err := g.GetChainById(77777); !errors.Is(err, ErrChainNotFound) {
+		t.Fatalf("GetChainById(77777) = %v, want ErrChainNotFound", err)
+	}
+	if _, err := g.GetNetworkBySlug("hoodi"); err != nil {
+		t.Fatalf("GetNetworkBySlug(hoodi) error: %v", err)
+	}
+	nets, err := g.ListNetworks()
+	if err != nil {
+		t.Fatalf("ListNetworks error: %v", err)
+	}
+	if len(nets) != 1 || nets[0].Slug() != "hoodi" {
+		t.Fatalf("guarded ListNetworks = %v, want [hoodi]", nets)
+	}
+}
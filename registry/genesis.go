@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	assets "github.com/compose-network/registry"
+)
+
+// zstdMagic is the frame magic number that prefixes zstd-compressed data.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// genesisRefPrefix prefixes content references in [genesis].file.
+const genesisRefPrefix = "sha256:"
+
+// GenesisBlobPath returns the data-relative path of the blob for a
+// "sha256:<hex>" reference, where <hex> is the SHA-256 of the uncompressed
+// genesis JSON.
+func GenesisBlobPath(ref string) (string, error) {
+	sum, ok := strings.CutPrefix(ref, genesisRefPrefix)
+	if !ok {
+		return "", fmt.Errorf("genesis reference %q: want %s<hex>", ref, genesisRefPrefix)
+	}
+	if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size || sum != strings.ToLower(sum) {
+		return "", fmt.Errorf("genesis reference %q: want 64 lowercase hex digits", ref)
+	}
+	return path.Join("genesis", "sha256", sum+".json.zst"), nil
+}
+
+// GenesisPath returns the data-relative path LoadGenesis reads for this
+// chain: the content-addressed blob named by [genesis].file, or
+// genesis/<network>/<slug>.json.zst when no reference is set.
+func (c Chain) GenesisPath() (string, error) {
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return c.genesisPath(cfg)
+}
+
+func (c Chain) genesisPath(cfg ChainConfig) (string, error) {
+	if cfg.Genesis.File == "" {
+		return path.Join("genesis", c.n.slug, c.slug+".json.zst"), nil
+	}
+	return GenesisBlobPath(cfg.Genesis.File)
+}
+
+// LoadGenesis returns the decompressed genesis JSON for this chain (see
+// GenesisPath). Content-addressed blobs are verified against their hash.
+//
+// The embedded registry returns ErrGenesisNotEmbedded when the binary was
+// built with the registry_nogenesis tag; otherwise a missing file yields
+// ErrGenesisNotFound.
+func (c Chain) LoadGenesis() ([]byte, error) {
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	p, err := c.genesisPath(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.Identifier(), err)
+	}
+	b, err := fs.ReadFile(c.n.r.fs, p)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read %s: %w", p, err)
+		}
+		if c.n.r.embedded && !assets.GenesisEmbedded {
+			return nil, fmt.Errorf("%w: %s", ErrGenesisNotEmbedded, c.Identifier())
+		}
+		return nil, fmt.Errorf("%w: %s", ErrGenesisNotFound, c.Identifier())
+	}
+	raw, err := decompressGenesis(b)
+	if err != nil {
+		return nil, fmt.Errorf("decompress %s: %w", p, err)
+	}
+	if cfg.Genesis.File != "" {
+		if got := GenesisRef(raw); got != cfg.Genesis.File {
+			return nil, fmt.Errorf("%s: content hash is %s, want %s", p, got, cfg.Genesis.File)
+		}
+	}
+	return raw, nil
+}
+
+// GenesisRef returns the "sha256:<hex>" content reference for an
+// uncompressed genesis JSON payload.
+func GenesisRef(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return genesisRefPrefix + hex.EncodeToString(sum[:])
+}
+
+// decompressGenesis decodes a zstd payload. Placeholder genesis files are
+// committed as plain JSON under the same name, so data without the zstd
+// magic is returned unchanged.
+func decompressGenesis(b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, zstdMagic) {
+		return b, nil
+	}
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	return dec.DecodeAll(b, nil)
+}
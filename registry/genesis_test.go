@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	assets "github.com/compose-network/registry"
+)
+
+func TestChain_LoadGenesis(t *testing.T) {
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	for _, id := range []string{"hoodi/rollup-a", "sepolia-dev/rollup-a"} {
+		c, err := r.GetChainByIdentifier(id)
+		if err != nil {
+			t.Fatalf("GetChainByIdentifier(%s) error: %v", id, err)
+		}
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) error: %v", id, err)
+		}
+		raw, err := c.LoadGenesis()
+		if err != nil {
+			t.Fatalf("LoadGenesis(%s) error: %v", id, err)
+		}
+		var g struct {
+			Config struct {
+				ChainID uint64 `json:"chainId"`
+			} `json:"config"`
+		}
+		if err := json.Unmarshal(raw, &g); err != nil {
+			t.Fatalf("decode genesis %s: %v", id, err)
+		}
+		if g.Config.ChainID != cfg.ChainID {
+			t.Fatalf("%s genesis chainId = %d, want %d", id, g.Config.ChainID, cfg.ChainID)
+		}
+	}
+}
+
+func TestChain_LoadGenesis_ContentAddressed(t *testing.T) {
+	raw := []byte(`{"config":{"chainId":1},"timestamp":1}`)
+	ref := GenesisRef(raw)
+	blob, err := GenesisBlobPath(ref)
+	if err != nil {
+		t.Fatalf("GenesisBlobPath error: %v", err)
+	}
+	tamperedBlob, _ := GenesisBlobPath(GenesisRef([]byte("{}")))
+	r, err := NewFromFS(fstest.MapFS{
+		"networks/x/compose.toml":  {Data: []byte(`name = "x"`)},
+		"networks/x/ok.toml":       {Data: []byte("chain_id = 1\n[genesis]\nfile = \"" + ref + "\"\n")},
+		"networks/x/missing.toml":  {Data: []byte("chain_id = 2\n[genesis]\nfile = \"sha256:" + strings.Repeat("0", 64) + "\"\n")},
+		"networks/x/bad-ref.toml":  {Data: []byte("chain_id = 3\n[genesis]\nfile = \"md5:abc\"\n")},
+		"networks/x/tampered.toml": {Data: []byte("chain_id = 4\n[genesis]\nfile = \"" + GenesisRef([]byte("{}")) + "\"\n")},
+		blob:                       {Data: raw},
+		tamperedBlob:               {Data: raw},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	load := func(slug string) ([]byte, error) {
+		c, err := r.GetChainByIdentifier("x/" + slug)
+		if err != nil {
+			t.Fatalf("GetChainByIdentifier(x/%s) error: %v", slug, err)
+		}
+		return c.LoadGenesis()
+	}
+	if got, err := load("ok"); err != nil || string(got) != string(raw) {
+		t.Fatalf("LoadGenesis(ok) = %q, %v", got, err)
+	}
+	if _, err := load("missing"); !errors.Is(err, ErrGenesisNotFound) {
+		t.Fatalf("expected ErrGenesisNotFound, got %v", err)
+	}
+	if _, err := load("bad-ref"); err == nil {
+		t.Fatalf("expected error for malformed reference")
+	}
+	if _, err := load("tampered"); err == nil || !strings.Contains(err.Error(), "content hash") {
+		t.Fatalf("expected content hash mismatch, got %v", err)
+	}
+}
+
+// TestEmbed_MatchesBuildTags checks that the embedded data matches data/ on
+// disk, minus whatever the active build tags exclude. make test-tags runs
+// it under each tag.
+func TestEmbed_MatchesBuildTags(t *testing.T) {
+	disk, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	all, err := disk.ListNetworks()
+	if err != nil {
+		t.Fatalf("ListNetworks(disk) error: %v", err)
+	}
+	// Devnets are left out by registry_nodev and hidden by the guard that
+	// registry_production puts on New.
+	var want []string
+	for _, n := range all {
+		env, err := n.Environment()
+		if err != nil {
+			t.Fatalf("Environment(%s) error: %v", n.Slug(), err)
+		}
+		if env != EnvDevnet || assets.DevNetworksEmbedded && !productionBuild {
+			want = append(want, n.Slug())
+		}
+	}
+	r := New()
+	nets, err := r.ListNetworks()
+	if err != nil {
+		t.Fatalf("ListNetworks error: %v", err)
+	}
+	var got []string
+	for _, n := range nets {
+		got = append(got, n.Slug())
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("embedded networks = %v, want %v", got, want)
+	}
+
+	// The guard hides devnet chains, whose genesis files are still embedded.
+	u := r
+	u.settings = nil
+	chains, err := u.ListChains()
+	if err != nil {
+		t.Fatalf("ListChains error: %v", err)
+	}
+	referenced := map[string]bool{}
+	for _, c := range chains {
+		p, err := c.GenesisPath()
+		if err != nil {
+			t.Fatalf("GenesisPath(%s) error: %v", c.Identifier(), err)
+		}
+		referenced[p] = true
+		_, err = c.LoadGenesis()
+		switch {
+		case assets.GenesisEmbedded && err != nil:
+			t.Fatalf("LoadGenesis(%s) error: %v", c.Identifier(), err)
+		case !assets.GenesisEmbedded && !errors.Is(err, ErrGenesisNotEmbedded):
+			t.Fatalf("LoadGenesis(%s) = %v, want ErrGenesisNotEmbedded", c.Identifier(), err)
+		}
+	}
+
+	// Only genesis files referenced by embedded chains should be compiled in.
+	if assets.GenesisEmbedded {
+		err := fs.WalkDir(r.fs, "genesis", func(p string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && !referenced[p] {
+				t.Errorf("embedded genesis %s is not referenced by any embedded chain", p)
+			}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("walk genesis: %v", err)
+		}
+	}
+
+	if !assets.DevNetworksEmbedded && !productionBuild {
+		var nf *NotFoundError
+		if _, err := r.GetNetworkBySlug("hoodi-dev"); !errors.As(err, &nf) || !strings.Contains(nf.Reason, "not embedded") {
+			t.Fatalf("expected ErrNetworkNotFound for excluded dev network, got %v", err)
+		}
+	}
+
+	// Trimmed builds carry only what the registry reads.
+	_, err = fs.Stat(r.fs, "chainList.json")
+	if full := assets.GenesisEmbedded && assets.DevNetworksEmbedded; full != (err == nil) {
+		t.Fatalf("chainList.json embedded = %v, want %v", err == nil, full)
+	}
+}
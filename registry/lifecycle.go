@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Status is the lifecycle stage of a network or chain, set by "status" in
+// its [lifecycle] table. An empty status means active.
+type Status string
+
+const (
+	StatusActive     Status = "active"
+	StatusDeprecated Status = "deprecated"
+	StatusRetired    Status = "retired"
+)
+
+// Valid reports whether s is one of the known statuses or empty.
+func (s Status) Valid() bool {
+	switch s {
+	case "", StatusActive, StatusDeprecated, StatusRetired:
+		return true
+	}
+	return false
+}
+
+// Lifecycle is decoded from the [lifecycle] table of a chain TOML or
+// compose.toml. Retired entries stay in the registry so that pinned
+// consumers keep resolving them.
+type Lifecycle struct {
+	Status Status `toml:"status"`
+	// DeprecatedOn is the date the entry was deprecated (a TOML local date).
+	DeprecatedOn time.Time `toml:"deprecated_on"`
+	// Replacement is the chain identifier ("<network>/<slug>") or network
+	// slug that consumers should move to.
+	Replacement string `toml:"replacement"`
+}
+
+// State returns the status, treating an empty one as StatusActive.
+func (l Lifecycle) State() Status {
+	if l.Status == "" {
+		return StatusActive
+	}
+	return l.Status
+}
+
+// Validate checks the status and requires deprecated_on on deprecated and
+// retired entries. Active entries must not set deprecated_on; a planned
+// deprecation is recorded when it happens.
+func (l Lifecycle) Validate() error {
+	switch {
+	case !l.Status.Valid():
+		return fmt.Errorf("lifecycle status must be active, deprecated or retired, got %q", l.Status)
+	case l.State() != StatusActive && l.DeprecatedOn.IsZero():
+		return fmt.Errorf("lifecycle: %s entries require deprecated_on", l.Status)
+	case l.State() == StatusActive && !l.DeprecatedOn.IsZero():
+		return errors.New("lifecycle: active entries must not set deprecated_on")
+	}
+	return nil
+}
+
+// Lifecycle returns the network's lifecycle metadata.
+func (n Network) Lifecycle() (Lifecycle, error) {
+	cfg, err := n.LoadConfig()
+	if err != nil {
+		return Lifecycle{}, err
+	}
+	return cfg.Lifecycle, nil
+}
+
+// Lifecycle returns the chain's lifecycle metadata.
+func (c Chain) Lifecycle() (Lifecycle, error) {
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return Lifecycle{}, err
+	}
+	return cfg.Lifecycle, nil
+}
+
+// ListOption filters ListNetworks and ListChains by lifecycle status. By
+// default every entry is listed.
+type ListOption func(*listOptions)
+
+type listOptions struct {
+	excludeDeprecated bool
+	excludeRetired    bool
+}
+
+// ExcludeDeprecated omits deprecated entries.
+func ExcludeDeprecated() ListOption {
+	return func(o *listOptions) { o.excludeDeprecated = true }
+}
+
+// ExcludeRetired omits retired entries.
+func ExcludeRetired() ListOption {
+	return func(o *listOptions) { o.excludeRetired = true }
+}
+
+// ActiveOnly omits deprecated and retired entries.
+func ActiveOnly() ListOption {
+	return func(o *listOptions) { o.excludeDeprecated, o.excludeRetired = true, true }
+}
+
+func newListOptions(opts []ListOption) listOptions {
+	var o listOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o listOptions) filtering() bool { return o.excludeDeprecated || o.excludeRetired }
+
+func (o listOptions) keep(l Lifecycle) bool {
+	switch l.State() {
+	case StatusDeprecated:
+		return !o.excludeDeprecated
+	case StatusRetired:
+		return !o.excludeRetired
+	}
+	return true
+}
+
+// Deprecation describes a deprecated or retired network or chain that a
+// lookup resolved.
+type Deprecation struct {
+	// Identifier is the network slug or the chain identifier.
+	Identifier string
+	Lifecycle  Lifecycle
+}
+
+// DeprecationHook is called by lookups that resolve a deprecated or
+// retired entry, typically to log a warning.
+type DeprecationHook func(Deprecation)
+
+// WithDeprecationHook calls fn whenever GetNetworkBySlug, GetNetworkById,
+// GetChainBySlug, GetChainById or GetChainByIdentifier resolves a
+// deprecated or retired entry. Listings do not call it.
+func WithDeprecationHook(fn DeprecationHook) Option {
+	return func(o *options) { o.deprecation = fn }
+}
+
+// notifyNetwork calls the deprecation hook for n if it is not active.
+// Config errors are left for LoadConfig to report.
+func (r Registry) notifyNetwork(n Network) {
+	hook := r.opts().deprecation
+	if hook == nil {
+		return
+	}
+	if l, err := n.Lifecycle(); err == nil && l.State() != StatusActive {
+		hook(Deprecation{Identifier: n.slug, Lifecycle: l})
+	}
+}
+
+// notifyChain calls the deprecation hook for c if it is not active.
+func (r Registry) notifyChain(c Chain) {
+	hook := r.opts().deprecation
+	if hook == nil {
+		return
+	}
+	if l, err := c.Lifecycle(); err == nil && l.State() != StatusActive {
+		hook(Deprecation{Identifier: c.Identifier(), Lifecycle: l})
+	}
+}
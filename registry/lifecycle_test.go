@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func lifecycleRegistry(t *testing.T, opts ...Option) Registry {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"networks/old/compose.toml": {Data: []byte(`environment = "testnet"
+[l1]
+chain_id = 1
+[lifecycle]
+status = "deprecated"
+deprecated_on = 2026-03-01
+replacement = "new"
+`)},
+		"networks/old/a.toml": {Data: []byte("chain_id = 10\n")},
+		"networks/new/compose.toml": {Data: []byte(`environment = "testnet"
+[l1]
+chain_id = 2
+`)},
+		"networks/new/a.toml": {Data: []byte("chain_id = 20\n")},
+		"networks/new/b.toml": {Data: []byte(`chain_id = 21
+[lifecycle]
+status = "deprecated"
+deprecated_on = 2026-03-01
+replacement = "new/a"
+`)},
+		"networks/new/c.toml": {Data: []byte(`chain_id = 22
+[lifecycle]
+status = "retired"
+deprecated_on = 2025-12-01
+`)},
+	}
+	r, err := NewFromFS(fsys, opts...)
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	return r
+}
+
+func identifiers(cs []Chain) []string {
+	out := make([]string, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, c.Identifier())
+	}
+	return out
+}
+
+func TestListChains_Lifecycle(t *testing.T) {
+	r := lifecycleRegistry(t)
+	cases := []struct {
+		name string
+		opts []ListOption
+		want []string
+	}{
+		{"all", nil, []string{"new/a", "new/b", "new/c", "old/a"}},
+		{"exclude retired", []ListOption{ExcludeRetired()}, []string{"new/a", "new/b", "old/a"}},
+		{"exclude deprecated", []ListOption{ExcludeDeprecated()}, []string{"new/a", "new/c"}},
+		{"active only", []ListOption{ActiveOnly()}, []string{"new/a"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cs, err := r.ListChains(tc.opts...)
+			if err != nil {
+				t.Fatalf("ListChains error: %v", err)
+			}
+			got := identifiers(cs)
+			if len(got) != len(tc.want) {
+				t.Fatalf("ListChains = %v, want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("ListChains = %v, want %v", got, tc.want)
+				}
+			}
+		})
+	}
+	nets, err := r.ListNetworks(ExcludeDeprecated())
+	if err != nil || len(nets) != 1 || nets[0].Slug() != "new" {
+		t.Fatalf("ListNetworks(ExcludeDeprecated) = %v, %v; want [new]", nets, err)
+	}
+}
+
+func TestDeprecationHook(t *testing.T) {
+	var got []Deprecation
+	r := lifecycleRegistry(t, WithDeprecationHook(func(d Deprecation) { got = append(got, d) }))
+
+	if _, err := r.GetChainByIdentifier("new/a"); err != nil {
+		t.Fatalf("GetChainByIdentifier(new/a) error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("hook called for active chain: %+v", got)
+	}
+	c, err := r.GetChainByIdentifier("new/b")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier(new/b) error: %v", err)
+	}
+	if c.Identifier() != "new/b" {
+		t.Fatalf("deprecated chain resolved to %s", c.Identifier())
+	}
+	if len(got) != 1 || got[0].Identifier != "new/b" || got[0].Lifecycle.Replacement != "new/a" {
+		t.Fatalf("hook calls = %+v, want one for new/b", got)
+	}
+	if d := got[0].Lifecycle.DeprecatedOn; d.Year() != 2026 || d.Month() != 3 || d.Day() != 1 {
+		t.Fatalf("deprecated_on = %v, want 2026-03-01", d)
+	}
+
+	got = nil
+	if _, err := r.GetChainById(22); err != nil {
+		t.Fatalf("GetChainById(22) error: %v", err)
+	}
+	if len(got) != 1 || got[0].Lifecycle.State() != StatusRetired {
+		t.Fatalf("hook calls = %+v, want one retired", got)
+	}
+
+	got = nil
+	if _, err := r.GetChainByIdentifier("old/a"); err != nil {
+		t.Fatalf("GetChainByIdentifier(old/a) error: %v", err)
+	}
+	if len(got) != 1 || got[0].Identifier != "old" {
+		t.Fatalf("hook calls = %+v, want one for network old", got)
+	}
+
+	got = nil
+	if _, err := r.ListChains(); err != nil || len(got) != 0 {
+		t.Fatalf("ListChains called hook %d times (err %v), want none", len(got), err)
+	}
+}
+
+func TestLifecycleValidate(t *testing.T) {
+	if err := (Lifecycle{}).Validate(); err != nil {
+		t.Fatalf("empty lifecycle: %v", err)
+	}
+	if err := (Lifecycle{Status: "sunset"}).Validate(); err == nil {
+		t.Fatal("unknown status accepted")
+	}
+	if err := (Lifecycle{Status: StatusDeprecated}).Validate(); err == nil {
+		t.Fatal("deprecated without deprecated_on accepted")
+	}
+	on := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := (Lifecycle{Status: StatusRetired, DeprecatedOn: on}).Validate(); err != nil {
+		t.Fatalf("retired with deprecated_on: %v", err)
+	}
+	for _, status := range []Status{"", StatusActive} {
+		if err := (Lifecycle{Status: status, DeprecatedOn: on}).Validate(); err == nil {
+			t.Fatalf("status %q with deprecated_on accepted", status)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// NotFoundKind says what a NotFoundError failed to find.
+type NotFoundKind string
+
+const (
+	KindNetwork NotFoundKind = "network"
+	KindChain   NotFoundKind = "chain"
+)
+
+// NotFoundError is returned by lookups that find no network or chain. It
+// matches ErrNetworkNotFound or ErrChainNotFound (by Kind) with errors.Is;
+// use errors.As to read the suggestions.
+type NotFoundError struct {
+	Kind NotFoundKind
+	// Key is the requested network slug, chain slug or chain id.
+	Key string
+	// Network is the network searched for a chain, if any.
+	Network string
+	// Suggestions are the closest existing network slugs or chain
+	// identifiers by edit distance, best first.
+	Suggestions []string
+	// Reason optionally explains why the entry is missing.
+	Reason string
+}
+
+func (e *NotFoundError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.sentinel().Error())
+	b.WriteString(": ")
+	if e.Network != "" {
+		b.WriteString(e.Network + "/")
+	}
+	b.WriteString(e.Key)
+	if e.Reason != "" {
+		fmt.Fprintf(&b, " (%s)", e.Reason)
+	}
+	if len(e.Suggestions) > 0 {
+		fmt.Fprintf(&b, " (did you mean %s?)", strings.Join(e.Suggestions, ", "))
+	}
+	return b.String()
+}
+
+// Is reports whether target is the sentinel for e.Kind.
+func (e *NotFoundError) Is(target error) bool { return target == e.sentinel() }
+
+func (e *NotFoundError) sentinel() error {
+	if e.Kind == KindNetwork {
+		return ErrNetworkNotFound
+	}
+	return ErrChainNotFound
+}
+
+// maxSuggestions caps NotFoundError.Suggestions.
+const maxSuggestions = 3
+
+// suggest returns up to maxSuggestions of the candidates closest to key,
+// sorted. Candidates more than a third of key's length away (at least 2
+// edits) are never suggested.
+func suggest(key string, candidates []string) []string {
+	limit := max(2, len(key)/3)
+	type scored struct {
+		s string
+		d int
+	}
+	var near []scored
+	for _, c := range candidates {
+		if d := editDistance(key, c); d <= limit && c != key {
+			near = append(near, scored{c, d})
+		}
+	}
+	slices.SortStableFunc(near, func(a, b scored) int {
+		if a.d != b.d {
+			return a.d - b.d
+		}
+		return strings.Compare(a.s, b.s)
+	})
+	var out []string
+	for _, c := range near {
+		if len(out) == maxSuggestions || c.d > near[0].d {
+			break
+		}
+		out = append(out, c.s)
+	}
+	return out
+}
+
+// editDistance is the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// networkNotFound builds a NotFoundError for slug with suggestions from the
+// listed networks.
+func (r Registry) networkNotFound(slug, reason string) error {
+	var slugs []string
+	if nets, err := r.ListNetworks(); err == nil {
+		for _, n := range nets {
+			slugs = append(slugs, n.slug)
+		}
+	}
+	return &NotFoundError{Kind: KindNetwork, Key: slug, Reason: reason, Suggestions: suggest(slug, slugs)}
+}
+
+// chainNotFound builds a NotFoundError for slug in n with suggestions from
+// n's chains, as identifiers.
+func (n Network) chainNotFound(slug string) error {
+	var ids []string
+	if chains, err := n.ListChains(); err == nil {
+		for _, c := range chains {
+			ids = append(ids, c.Identifier())
+		}
+	}
+	return &NotFoundError{Kind: KindChain, Key: slug, Network: n.slug, Suggestions: suggest(n.slug+"/"+slug, ids)}
+}
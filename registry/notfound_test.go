@@ -0,0 +1,70 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"hoodi", "hoodi", 0},
+		{"hodi", "hoodi", 1},
+		{"rollup-z", "rollup-a", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tc := range cases {
+		if got := editDistance(tc.a, tc.b); got != tc.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestNotFoundError_Suggestions(t *testing.T) {
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		kind NotFoundKind
+		want string
+	}{
+		{"network", second(r.GetNetworkBySlug("hodi")), KindNetwork, "hoodi"},
+		{"identifier network typo", second(r.GetChainByIdentifier("hodi/rollup-a")), KindNetwork, "hoodi"},
+		{"identifier chain typo", second(r.GetChainByIdentifier("hoodi/rollup-z")), KindChain, "hoodi/rollup-a,hoodi/rollup-b"},
+		{"resolve slug", second(r.Resolve("rolup-a")), KindChain, "rollup-a"},
+		{"far off", second(r.GetNetworkBySlug("mainnet")), KindNetwork, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var nf *NotFoundError
+			if !errors.As(tc.err, &nf) {
+				t.Fatalf("error = %v, want *NotFoundError", tc.err)
+			}
+			if nf.Kind != tc.kind {
+				t.Fatalf("Kind = %s, want %s", nf.Kind, tc.kind)
+			}
+			if got := strings.Join(nf.Suggestions, ","); got != tc.want {
+				t.Fatalf("Suggestions = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	err = second(r.GetChainByIdentifier("hoodi/rollup-z"))
+	if !errors.Is(err, ErrChainNotFound) || errors.Is(err, ErrNetworkNotFound) {
+		t.Fatalf("errors.Is mismatch for %v", err)
+	}
+	if want := "chain not found: hoodi/rollup-z (did you mean hoodi/rollup-a, hoodi/rollup-b?)"; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func second[T any](_ T, err error) error { return err }
@@ -0,0 +1,276 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Option configures a Registry at construction; see New, NewFromDir and
+// NewFromFS. Without options the constructors behave as before.
+type Option func(*options)
+
+type options struct {
+	fsys        fs.FS
+	embedded    bool // the data is the embedded assets; see Registry.Options
+	strict      bool
+	validate    bool
+	cache       bool
+	guard       bool
+	normalize   bool
+	deprecation DeprecationHook
+	include     []string
+	exclude     []string
+}
+
+// WithStrictDecoding makes LoadConfig fail on TOML keys that the config
+// structs do not define, catching typos such as "chainid".
+func WithStrictDecoding() Option {
+	return func(o *options) { o.strict = true }
+}
+
+// WithEagerValidation runs Registry.Validate at construction, failing on
+// any network or chain config that does not decode or breaks a rule. New
+// reports failures through Registry.Err.
+func WithEagerValidation() Option {
+	return func(o *options) { o.validate = true }
+}
+
+// WithIndexCache keeps directory listings, TOML files and the alias index
+// in memory after their first read, so repeated listings, id lookups and
+// alias misses do not hit the backing fs again. The data must not change
+// while the registry is in use.
+func WithIndexCache() Option {
+	return func(o *options) { o.cache = true }
+}
+
+// WithNetworks restricts the registry to the given network slugs, which
+// must exist. Other networks are not listed and their lookups return
+// ErrNetworkNotFound.
+func WithNetworks(slugs ...string) Option {
+	return func(o *options) { o.include = append(o.include, slugs...) }
+}
+
+// WithoutNetworks hides the given network slugs as WithNetworks hides the
+// ones it does not name.
+func WithoutNetworks(slugs ...string) Option {
+	return func(o *options) { o.exclude = append(o.exclude, slugs...) }
+}
+
+// WithFS makes New read from fsys, which must have the data layout at its
+// root, instead of the embedded assets. NewFromDir and NewFromFS reject it.
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) { o.fsys = fsys }
+}
+
+// settings holds the option state a Registry needs after construction. It
+// is shared by pointer so that Registry stays comparable.
+type settings struct {
+	opts    options         // as given, without fsys; see Registry.Options
+	include map[string]bool // nil: all networks
+	exclude map[string]bool
+	err     error        // construction error reported by Err
+	aliases *aliasIndex  // set by WithIndexCache
+	guard   *devnetGuard // set by WithDevnetGuard
+}
+
+// Err returns the error recorded while constructing r with New, such as an
+// eager validation failure. Lookups on such a registry return it too.
+func (r Registry) Err() error {
+	if r.settings == nil {
+		return nil
+	}
+	return r.settings.err
+}
+
+// apply configures r from opts. fromFS reports whether the caller already
+// chose the fs, in which case WithFS is an error.
+func (r Registry) apply(opts []Option, fromFS bool) (Registry, error) {
+	if len(opts) == 0 {
+		return r, nil
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.fsys != nil {
+		if fromFS {
+			return Registry{}, errors.New("registry: WithFS is only valid with New")
+		}
+		if fi, err := fs.Stat(o.fsys, "networks"); err != nil || !fi.IsDir() {
+			return Registry{}, errors.New("registry: networks directory not found in fs")
+		}
+		if err := checkSlugs(o.fsys); err != nil {
+			return Registry{}, fmt.Errorf("registry: %w", err)
+		}
+		r.fs, r.embedded = o.fsys, false
+	}
+	r.embedded = r.embedded || o.embedded
+	if o.cache {
+		r.fs = &cachedFS{FS: r.fs}
+	}
+	var errs []error
+	for _, slug := range o.include {
+		if err := ValidateSlug(slug); err != nil {
+			errs = append(errs, fmt.Errorf("WithNetworks: %w", err))
+		} else if fi, err := fs.Stat(r.fs, path.Join("networks", slug)); err != nil || !fi.IsDir() {
+			errs = append(errs, fmt.Errorf("WithNetworks: %w", r.networkNotFound(slug, "")))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return Registry{}, fmt.Errorf("registry: %w", err)
+	}
+	o.fsys = nil
+	s := &settings{opts: o}
+	if o.cache {
+		s.aliases = &aliasIndex{}
+	}
+	if o.guard {
+		s.guard = &devnetGuard{}
+	}
+	if len(o.include) > 0 {
+		s.include = make(map[string]bool, len(o.include))
+		for _, slug := range o.include {
+			s.include[slug] = true
+		}
+	}
+	if len(o.exclude) > 0 {
+		s.exclude = make(map[string]bool, len(o.exclude))
+		for _, slug := range o.exclude {
+			s.exclude[slug] = true
+		}
+	}
+	r.settings = s
+	if o.validate {
+		if err := r.Validate(); err != nil {
+			return Registry{}, fmt.Errorf("registry: %w", err)
+		}
+	}
+	return r, nil
+}
+
+// visible reports whether the network filters let slug through.
+func (r Registry) visible(slug string) bool {
+	s := r.settings
+	if s == nil {
+		return true
+	}
+	return (s.include == nil || s.include[slug]) && !s.exclude[slug]
+}
+
+// Options returns options that configure another registry as r is
+// configured, including the devnet guard New turns on in production builds
+// and whether r reads the embedded assets. Pass them to NewFromFS to serve
+// other data, such as an overlay of r.FS(), with r's behavior.
+func (r Registry) Options() []Option {
+	o := r.opts()
+	var opts []Option
+	if r.embedded {
+		opts = append(opts, func(o *options) { o.embedded = true })
+	}
+	if o.strict {
+		opts = append(opts, WithStrictDecoding())
+	}
+	if o.validate {
+		opts = append(opts, WithEagerValidation())
+	}
+	if o.cache {
+		opts = append(opts, WithIndexCache())
+	}
+	if o.guard {
+		opts = append(opts, WithDevnetGuard())
+	}
+	if o.normalize {
+		opts = append(opts, WithNormalizedSlugs())
+	}
+	if o.deprecation != nil {
+		opts = append(opts, WithDeprecationHook(o.deprecation))
+	}
+	if len(o.include) > 0 {
+		opts = append(opts, WithNetworks(o.include...))
+	}
+	if len(o.exclude) > 0 {
+		opts = append(opts, WithoutNetworks(o.exclude...))
+	}
+	return opts
+}
+
+// opts returns the options r was built with.
+func (r Registry) opts() options {
+	if r.settings == nil {
+		return options{}
+	}
+	return r.settings.opts
+}
+
+// strict reports whether LoadConfig rejects unknown keys.
+func (r Registry) strict() bool { return r.opts().strict }
+
+// undecoded returns an error naming keys that were not decoded, other
+// than those in the free-form [extra] table and the [addresses] table,
+// whose contract names vary by deployment (ChainConfig decodes only the
+// Mailbox; use Chain.Decode for the rest).
+func undecoded(keys []toml.Key) error {
+	var names []string
+	for _, k := range keys {
+		if len(k) > 0 && (k[0] == extraTable || k[0] == addressesTable) {
+			continue
+		}
+		names = append(names, k.String())
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("unknown keys: %s", strings.Join(names, ", "))
+}
+
+// cachedFS memoizes ReadDir and ReadFile of TOML files. Genesis blobs are
+// read through uncached.
+type cachedFS struct {
+	fs.FS
+	mu    sync.Mutex
+	dirs  map[string][]fs.DirEntry
+	files map[string][]byte
+}
+
+func (c *cachedFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.dirs[name]; ok {
+		return e, nil
+	}
+	e, err := fs.ReadDir(c.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	if c.dirs == nil {
+		c.dirs = make(map[string][]fs.DirEntry)
+	}
+	c.dirs[name] = e
+	return e, nil
+}
+
+func (c *cachedFS) ReadFile(name string) ([]byte, error) {
+	if !strings.HasSuffix(name, ".toml") {
+		return fs.ReadFile(c.FS, name)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := c.files[name]; ok {
+		return append([]byte(nil), b...), nil
+	}
+	b, err := fs.ReadFile(c.FS, name)
+	if err != nil {
+		return nil, err
+	}
+	if c.files == nil {
+		c.files = make(map[string][]byte)
+	}
+	c.files[name] = b
+	return append([]byte(nil), b...), nil
+}
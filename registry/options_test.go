@@ -0,0 +1,183 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestNew_Options(t *testing.T) {
+	r := New(WithStrictDecoding(), WithEagerValidation(), WithIndexCache())
+	if err := r.Err(); err != nil {
+		t.Fatalf("embedded registry fails strict validation: %v", err)
+	}
+	c, err := r.GetChainByIdentifier("hoodi/rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier error: %v", err)
+	}
+	for i := 0; i < 2; i++ { // second read is served from the cache
+		if cfg, err := c.LoadConfig(); err != nil || cfg.ChainID != 11113 {
+			t.Fatalf("LoadConfig = %d, %v", cfg.ChainID, err)
+		}
+	}
+	if _, err := c.LoadGenesis(); err != nil && !errors.Is(err, ErrGenesisNotEmbedded) {
+		t.Fatalf("LoadGenesis through cache error: %v", err)
+	}
+}
+
+func TestNew_NetworkFilters(t *testing.T) {
+	r := New(WithNetworks("hoodi"))
+	nets, err := r.ListNetworks()
+	if err != nil || len(nets) != 1 || nets[0].Slug() != "hoodi" {
+		t.Fatalf("ListNetworks = %v, %v; want [hoodi]", nets, err)
+	}
+	if _, err := r.GetNetworkBySlug("sepolia-dev"); !errors.Is(err, ErrNetworkNotFound) {
+		t.Fatalf("GetNetworkBySlug(sepolia-dev) error = %v, want ErrNetworkNotFound", err)
+	}
+	if _, err := r.GetChainById(11113); err != nil {
+		t.Fatalf("GetChainById(11113) error: %v", err)
+	}
+
+	r = New(WithoutNetworks("hoodi"))
+	if _, err := r.GetChainByIdentifier("hoodi/rollup-a"); !errors.Is(err, ErrNetworkNotFound) {
+		t.Fatalf("excluded GetChainByIdentifier error = %v, want ErrNetworkNotFound", err)
+	}
+
+	r = New(WithNetworks("mainnet"))
+	if !errors.Is(r.Err(), ErrNetworkNotFound) {
+		t.Fatalf("Err() = %v, want ErrNetworkNotFound for unknown include", r.Err())
+	}
+	if _, err := r.ListNetworks(); !errors.Is(err, r.Err()) {
+		t.Fatalf("ListNetworks error = %v, want sticky Err()", err)
+	}
+}
+
+func TestNew_WithFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte("environment = \"testnet\"\n[l1]\nchain_id = 1\n")},
+		"networks/testnet/rollup.toml":  {Data: []byte("chain_id = 2\nchainid = 3\n")},
+	}
+	r := New(WithFS(fsys))
+	c, err := r.GetChainByIdentifier("testnet/rollup")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier error: %v", err)
+	}
+	if _, err := c.LoadConfig(); err != nil {
+		t.Fatalf("lenient LoadConfig error: %v", err)
+	}
+
+	strict := New(WithFS(fsys), WithStrictDecoding())
+	c, _ = strict.GetChainByIdentifier("testnet/rollup")
+	if _, err := c.LoadConfig(); err == nil || !strings.Contains(err.Error(), "unknown keys: chainid") {
+		t.Fatalf("strict LoadConfig error = %v, want unknown key chainid", err)
+	}
+	if err := New(WithFS(fsys), WithStrictDecoding(), WithEagerValidation()).Err(); err == nil {
+		t.Fatal("eager strict validation accepted an unknown key")
+	}
+	if _, err := NewFromFS(fsys, WithFS(fsys)); err == nil {
+		t.Fatal("NewFromFS accepted WithFS")
+	}
+}
+
+func TestStrictDecoding_Addresses(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte("environment = \"testnet\"\n[l1]\nchain_id = 1\n")},
+		"networks/testnet/rollup.toml": {Data: []byte(`chain_id = 2
+
+[addresses]
+Mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+L1StandardBridgeProxy = "0x54e692F4e290409035a9cC6A3d55eB047c82112C"
+` + etherTable)},
+	}
+	if _, err := NewFromFS(fsys, WithStrictDecoding(), WithEagerValidation()); err != nil {
+		t.Fatalf("strict eager validation rejected an imported [addresses] table: %v", err)
+	}
+}
+
+func TestRegistry_Options(t *testing.T) {
+	base, err := NewFromDir("../data", WithDevnetGuard(), WithNormalizedSlugs(), WithoutNetworks("sepolia-dev"))
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	r, err := NewFromFS(base.FS(), base.Options()...)
+	if err != nil {
+		t.Fatalf("NewFromFS(base.Options()) error: %v", err)
+	}
+	if _, err := r.GetNetworkBySlug("hoodi-dev"); !errors.Is(err, ErrDevnetForbidden) {
+		t.Fatalf("GetNetworkBySlug(hoodi-dev) error = %v, want ErrDevnetForbidden", err)
+	}
+	if _, err := r.GetChainByIdentifier(" Hoodi/Rollup-A "); err != nil {
+		t.Fatalf("normalized GetChainByIdentifier error: %v", err)
+	}
+	nets, err := r.ListNetworks()
+	if err != nil || len(nets) != 1 || nets[0].Slug() != "hoodi" {
+		t.Fatalf("ListNetworks = %v, %v; want [hoodi]", nets, err)
+	}
+	if r.embedded {
+		t.Fatal("registry built from a directory's options reads as embedded")
+	}
+
+	e := New()
+	r, err = NewFromFS(e.FS(), e.Options()...)
+	if err != nil {
+		t.Fatalf("NewFromFS(New().Options()) error: %v", err)
+	}
+	if !r.embedded {
+		t.Fatal("registry built from the embedded registry's options does not read as embedded")
+	}
+	if _, err := r.GetNetworkBySlug("hoodi-dev"); productionBuild != errors.Is(err, ErrDevnetForbidden) {
+		t.Fatalf("GetNetworkBySlug(hoodi-dev) error = %v, production build = %v", err, productionBuild)
+	}
+}
+
+func TestStrictDecoding_ToolKeys(t *testing.T) {
+	fsys := fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte("environment = \"testnet\"\n[l1]\nchain_id = 1\n")},
+		"networks/testnet/rollup.toml": {Data: []byte(`chain_id = 2
+data_availability_type = "alt-da"
+
+[roles]
+SystemConfigOwner = "0x1111111111111111111111111111111111111111"
+` + etherTable)},
+	}
+	r, err := NewFromFS(fsys, WithStrictDecoding(), WithEagerValidation())
+	if err != nil {
+		t.Fatalf("strict eager validation rejected keys the tools read: %v", err)
+	}
+	c, _ := r.GetChainByIdentifier("testnet/rollup")
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig error: %v", err)
+	}
+	if cfg.DataAvailabilityType != "alt-da" || cfg.Roles["SystemConfigOwner"] != "0x1111111111111111111111111111111111111111" {
+		t.Fatalf("LoadConfig = %q, %v", cfg.DataAvailabilityType, cfg.Roles)
+	}
+}
+
+// TestStrictDecoding_Data strict-decodes every committed network and chain,
+// including those build tags leave out of the embed.
+func TestStrictDecoding_Data(t *testing.T) {
+	r, err := NewFromDir("../data", WithStrictDecoding())
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	nets, err := r.ListNetworks()
+	if err != nil {
+		t.Fatalf("ListNetworks error: %v", err)
+	}
+	for _, n := range nets {
+		if _, err := n.LoadConfig(); err != nil {
+			t.Errorf("strict LoadConfig(%s): %v", n.Slug(), err)
+		}
+		chains, err := n.ListChains()
+		if err != nil {
+			t.Fatalf("ListChains(%s) error: %v", n.Slug(), err)
+		}
+		for _, c := range chains {
+			if _, err := c.LoadConfig(); err != nil {
+				t.Errorf("strict LoadConfig(%s): %v", c.Identifier(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,6 @@
+//go:build registry_production
+
+package registry
+
+// productionBuild enables the devnet guard on the registry returned by New.
+const productionBuild = true
@@ -0,0 +1,5 @@
+//go:build !registry_production
+
+package registry
+
+const productionBuild = false
@@ -0,0 +1,114 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// extraTable is the reserved TOML table for custom metadata. The registry
+// never defines keys in it; see ChainConfig.Extra and DecodeExtra.
+const extraTable = "extra"
+
+// addressesTable holds a chain's contract addresses by contract name.
+const addressesTable = "addresses"
+
+// RawTOML returns the bytes of networks/<network>/<slug>.toml.
+func (c Chain) RawTOML() ([]byte, error) {
+	s := strings.TrimSpace(c.slug)
+	if s == "" {
+		return nil, errors.New("empty chain slug")
+	}
+	b, err := fs.ReadFile(c.n.r.fs, c.configPath())
+	if err != nil {
+		return nil, &NotFoundError{Kind: KindChain, Key: s, Network: c.n.slug}
+	}
+	return b, nil
+}
+
+// Decode decodes the chain's TOML into v, which may declare keys that
+// ChainConfig does not know yet. Unknown keys are ignored.
+func (c Chain) Decode(v any) error {
+	_, err := c.decode(v)
+	return err
+}
+
+// DecodeExtra decodes the chain's [extra] table into v. v is left
+// unchanged when the table is absent.
+func (c Chain) DecodeExtra(v any) error {
+	var raw struct {
+		Extra toml.Primitive `toml:"extra"`
+	}
+	md, err := c.decode(&raw)
+	if err != nil || !md.IsDefined(extraTable) {
+		return err
+	}
+	if err := md.PrimitiveDecode(raw.Extra, v); err != nil {
+		return fmt.Errorf("decode %s [%s]: %w", c.configPath(), extraTable, err)
+	}
+	return nil
+}
+
+func (c Chain) decode(v any) (toml.MetaData, error) {
+	b, err := c.RawTOML()
+	if err != nil {
+		return toml.MetaData{}, err
+	}
+	md, err := toml.Decode(string(b), v)
+	if err != nil {
+		return md, fmt.Errorf("decode %s: %w", c.configPath(), err)
+	}
+	return md, nil
+}
+
+func (c Chain) configPath() string {
+	return path.Join("networks", c.n.slug, strings.TrimSpace(c.slug)+".toml")
+}
+
+// RawTOML returns the bytes of networks/<slug>/compose.toml.
+func (n Network) RawTOML() ([]byte, error) {
+	b, err := fs.ReadFile(n.r.fs, path.Join("networks", n.slug, "compose.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("read compose.toml for %s: %w", n.slug, err)
+	}
+	return b, nil
+}
+
+// Decode decodes the network's compose.toml into v, which may declare keys
+// that NetworkConfig does not know yet. Unknown keys are ignored.
+func (n Network) Decode(v any) error {
+	_, err := n.decode(v)
+	return err
+}
+
+// DecodeExtra decodes the [extra] table of compose.toml into v. v is left
+// unchanged when the table is absent.
+func (n Network) DecodeExtra(v any) error {
+	var raw struct {
+		Extra toml.Primitive `toml:"extra"`
+	}
+	md, err := n.decode(&raw)
+	if err != nil || !md.IsDefined(extraTable) {
+		return err
+	}
+	if err := md.PrimitiveDecode(raw.Extra, v); err != nil {
+		return fmt.Errorf("decode compose.toml for %s [%s]: %w", n.slug, extraTable, err)
+	}
+	return nil
+}
+
+func (n Network) decode(v any) (toml.MetaData, error) {
+	b, err := n.RawTOML()
+	if err != nil {
+		return toml.MetaData{}, err
+	}
+	md, err := toml.Decode(string(b), v)
+	if err != nil {
+		return md, fmt.Errorf("decode compose.toml for %s: %w", n.slug, err)
+	}
+	return md, nil
+}
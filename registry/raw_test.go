@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const rawChainTOML = `chain_id = 11113
+bridge_url = "https://bridge.example"
+
+[extra]
+team = "payments"
+tier = 2
+
+[extra.alerts]
+channel = "#rollup-a"
+`
+
+func TestChain_DecodeRawAndExtra(t *testing.T) {
+	r, err := NewFromFS(fstest.MapFS{
+		"networks/testnet/compose.toml":  {Data: []byte("[l1]\nchain_id = 1\n[extra]\nowner = \"infra\"\n")},
+		"networks/testnet/rollup-a.toml": {Data: []byte(rawChainTOML)},
+		"networks/testnet/rollup-b.toml": {Data: []byte("chain_id = 22224\n")},
+	}, WithStrictDecoding())
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	c, err := r.GetChainByIdentifier("testnet/rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier error: %v", err)
+	}
+
+	raw, err := c.RawTOML()
+	if err != nil || string(raw) != rawChainTOML {
+		t.Fatalf("RawTOML = %q, %v", raw, err)
+	}
+	var future struct {
+		ChainID   uint64 `toml:"chain_id"`
+		BridgeURL string `toml:"bridge_url"`
+	}
+	if err := c.Decode(&future); err != nil || future.BridgeURL != "https://bridge.example" || future.ChainID != 11113 {
+		t.Fatalf("Decode = %+v, %v", future, err)
+	}
+
+	// bridge_url is unknown to ChainConfig, so strict LoadConfig rejects it,
+	// but [extra] is always accepted.
+	if _, err := c.LoadConfig(); err == nil || !strings.Contains(err.Error(), "bridge_url") || strings.Contains(err.Error(), "extra") {
+		t.Fatalf("strict LoadConfig error = %v, want only bridge_url reported", err)
+	}
+
+	var meta struct {
+		Team   string `toml:"team"`
+		Tier   int    `toml:"tier"`
+		Alerts struct {
+			Channel string `toml:"channel"`
+		} `toml:"alerts"`
+	}
+	if err := c.DecodeExtra(&meta); err != nil {
+		t.Fatalf("DecodeExtra error: %v", err)
+	}
+	if meta.Team != "payments" || meta.Tier != 2 || meta.Alerts.Channel != "#rollup-a" {
+		t.Fatalf("DecodeExtra = %+v", meta)
+	}
+
+	b, _ := r.GetChainByIdentifier("testnet/rollup-b")
+	meta.Team = "unchanged"
+	if err := b.DecodeExtra(&meta); err != nil || meta.Team != "unchanged" {
+		t.Fatalf("DecodeExtra without [extra] = %+v, %v", meta, err)
+	}
+	cfg, err := b.LoadConfig()
+	if err != nil || cfg.Extra != nil {
+		t.Fatalf("LoadConfig without [extra]: Extra = %v, %v", cfg.Extra, err)
+	}
+
+	n := c.Network()
+	var nmeta struct {
+		Owner string `toml:"owner"`
+	}
+	if err := n.DecodeExtra(&nmeta); err != nil || nmeta.Owner != "infra" {
+		t.Fatalf("Network.DecodeExtra = %+v, %v", nmeta, err)
+	}
+	if ncfg, err := n.LoadConfig(); err != nil || ncfg.Extra["owner"] != "infra" {
+		t.Fatalf("NetworkConfig.Extra = %v, %v", ncfg.Extra, err)
+	}
+}
@@ -0,0 +1,24 @@
+package registry
+
+// Reader is the registry-level lookup API of Registry. Accept a Reader
+// instead of a Registry to let tests inject a fake or a wrapper.
+//
+// Reader covers the Registry methods only. Network and Chain stay concrete
+// handles bound to the registry that made them: a fake cannot return
+// handles of its own, and their methods (LoadConfig, LoadGenesis,
+// ListChains, ...) always read that registry's data. A fake therefore
+// returns handles from a real registry, typically one built with
+// registrytest.NewBuilder, and controls what LoadConfig and LoadGenesis
+// return through the data it builds.
+type Reader interface {
+	ListNetworks(opts ...ListOption) ([]Network, error)
+	ListNetworksByEnvironment(env Environment) ([]Network, error)
+	GetNetworkBySlug(slug string) (Network, error)
+	GetNetworkById(l1ChainId uint64) (Network, error)
+	ListChains(opts ...ListOption) ([]Chain, error)
+	GetChainByIdentifier(identifier string) (Chain, error)
+	GetChainById(l2ChainId uint64) (Chain, error)
+	Resolve(selector string) (Resolved, error)
+}
+
+var _ Reader = Registry{}
@@ -7,11 +7,11 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/BurntSushi/toml"
-
 	assets "github.com/compose-network/registry"
 )
 
@@ -19,33 +19,76 @@ import (
 var (
 	ErrNetworkNotFound = errors.New("network not found")
 	ErrChainNotFound   = errors.New("chain not found")
+	ErrGenesisNotFound = errors.New("genesis not found")
+	// ErrGenesisNotEmbedded is returned by the embedded registry when the
+	// binary was built with the registry_nogenesis tag.
+	ErrGenesisNotEmbedded = errors.New("genesis not embedded in this build")
 )
 
 // Registry provides access to the embedded registry (default) or a directory on disk.
 // It owns a normalized fs rooted at the data/ folder, so lookups use paths like
 // "networks/<network>/<chain>.toml".
-type Registry struct{ fs fs.FS }
+type Registry struct {
+	fs       fs.FS
+	embedded bool
+	settings *settings // see Option
+}
 
-// New returns a Registry backed by the embedded assets under data/.
-func New() Registry {
+// New returns a Registry backed by the embedded assets under data/ (or the
+// fs given with WithFS). Build tags may exclude genesis files or dev
+// networks; see package assets. In binaries built with the
+// registry_production tag it has the devnet guard on. If an option fails, Err and every lookup
+// return the error.
+func New(opts ...Option) Registry {
 	sub, _ := fs.Sub(assets.FS, "data")
-	return Registry{fs: sub}
+	r := Registry{fs: sub, embedded: true}
+	if productionBuild {
+		opts = append([]Option{WithDevnetGuard()}, opts...)
+	}
+	out, err := r.apply(opts, false)
+	if err != nil {
+		r.settings = &settings{err: err}
+		return r
+	}
+	return out
 }
 
 // NewFromDir returns a Registry backed by a directory on disk that contains
 // a data layout compatible with the embedded one (expects a "networks/" directory).
-func NewFromDir(dir string) (Registry, error) {
+// Network directory and chain file names must be valid slugs (ErrInvalidSlug).
+func NewFromDir(dir string, opts ...Option) (Registry, error) {
 	// Validate that dir contains a networks/ directory
 	if fi, err := os.Stat(filepath.Join(dir, "networks")); err != nil || !fi.IsDir() {
 		return Registry{}, fmt.Errorf("registry: networks directory not found in %q", dir)
 	}
-	return Registry{fs: os.DirFS(dir)}, nil
+	fsys := os.DirFS(dir)
+	if err := checkSlugs(fsys); err != nil {
+		return Registry{}, fmt.Errorf("registry: %s: %w", dir, err)
+	}
+	return Registry{fs: fsys}.apply(opts, true)
 }
 
+// NewFromFS returns a Registry backed by fsys, which must have the data
+// layout at its root (expects a "networks/" directory). Names are checked
+// as in NewFromDir.
+func NewFromFS(fsys fs.FS, opts ...Option) (Registry, error) {
+	if fi, err := fs.Stat(fsys, "networks"); err != nil || !fi.IsDir() {
+		return Registry{}, fmt.Errorf("registry: networks directory not found in fs")
+	}
+	if err := checkSlugs(fsys); err != nil {
+		return Registry{}, fmt.Errorf("registry: %w", err)
+	}
+	return Registry{fs: fsys}.apply(opts, true)
+}
+
+// FS returns the filesystem backing r, rooted at the data layout.
+func (r Registry) FS() fs.FS { return r.fs }
+
 // Network is a lightweight network handle (slug-only). Use LoadConfig to decode TOML.
 type Network struct {
-	slug string
-	r    Registry
+	slug  string
+	r     Registry
+	alias string // slug used for the lookup, when it was an alias
 }
 
 // Slug returns the network slug.
@@ -53,8 +96,9 @@ func (n Network) Slug() string { return n.slug }
 
 // Chain is a lightweight chain handle (slug + parent network).
 type Chain struct {
-	slug string
-	n    Network
+	slug  string
+	n     Network
+	alias string // identifier used for the lookup, when it involved an alias
 }
 
 // Slug returns the chain slug.
@@ -68,14 +112,30 @@ func (c Chain) Identifier() string { return c.n.slug + "/" + c.slug }
 
 // ChainConfig is decoded from networks/<network>/<slug>.toml.
 type ChainConfig struct {
-	Name      string `toml:"name"`
-	ChainID   uint64 `toml:"chain_id"`
-	PublicRPC string `toml:"public_rpc"`
-	Explorer  string `toml:"explorer"`
-	Addresses struct {
-		Mailbox string `toml:"Mailbox"`
+	Name string `toml:"name"`
+	// Aliases are former slugs that still resolve to this chain within its
+	// network, e.g. after renaming the TOML file.
+	Aliases   []string `toml:"aliases"`
+	ChainID   uint64   `toml:"chain_id"`
+	PublicRPC string   `toml:"public_rpc"`
+	Explorer  string   `toml:"explorer"`
+	// ExplorerAPI is the explorer's Etherscan-compatible API endpoint, if known.
+	ExplorerAPI string `toml:"explorer_api"`
+	// NativeCurrency is the chain's gas token. [native_currency] is required;
+	// Registry.Validate rejects a chain without it.
+	NativeCurrency NativeCurrency `toml:"native_currency"`
+	// DataAvailabilityType is "eth-da" or "alt-da"; empty means eth-da.
+	DataAvailabilityType string `toml:"data_availability_type"`
+	Addresses            struct {
+		Mailbox Address `toml:"Mailbox"`
 	} `toml:"addresses"`
+	// Roles maps OP Stack role names, e.g. "SystemConfigOwner", to the
+	// addresses holding them.
+	Roles   map[string]Address `toml:"roles"`
 	Genesis struct {
+		// File is a content reference "sha256:<hex>" to a blob under
+		// genesis/sha256/. Empty means genesis/<network>/<slug>.json.zst.
+		File   string `toml:"file"`
 		L2Time uint64 `toml:"l2_time"`
 	} `toml:"genesis"`
 	Sequencer struct {
@@ -83,25 +143,61 @@ type ChainConfig struct {
 		Port        int      `toml:"port"`
 		AuthPubkeys []string `toml:"auth_pubkeys"`
 	} `toml:"sequencer"`
+	Lifecycle Lifecycle `toml:"lifecycle"`
+	// Placeholders lists address fields ("addresses.<name>") known to hold
+	// a zero or placeholder value; see Registry.Validate.
+	Placeholders []string `toml:"placeholders"`
+	// Extra is the reserved [extra] table for custom metadata; see
+	// Chain.DecodeExtra for typed access.
+	Extra map[string]any `toml:"extra"`
+}
+
+// NativeCurrency is decoded from the [native_currency] table of a chain TOML.
+// L1Token is set for custom gas token chains: the L1 ERC-20 backing the
+// native currency. Empty means the chain pays gas in ETH.
+type NativeCurrency struct {
+	Name     string  `toml:"name"`
+	Symbol   string  `toml:"symbol"`
+	Decimals uint8   `toml:"decimals"`
+	L1Token  Address `toml:"l1_token"`
 }
 
 // NetworkConfig is decoded from networks/<slug>/compose.toml.
 type NetworkConfig struct {
 	Name string `toml:"name"`
-	L1   struct {
-		ChainID   uint64 `toml:"chain_id"`
-		PublicRPC string `toml:"public_rpc"`
-		Explorer  string `toml:"explorer"`
+	// Aliases are former slugs that still resolve to this network.
+	Aliases []string `toml:"aliases"`
+	// Environment is mainnet, testnet or devnet. It decides which networks
+	// the devnet guard and the registry_nodev build tag exclude.
+	Environment Environment `toml:"environment"`
+	L1          struct {
+		ChainID     uint64 `toml:"chain_id"`
+		PublicRPC   string `toml:"public_rpc"`
+		Explorer    string `toml:"explorer"`
+		ExplorerAPI string `toml:"explorer_api"` // Etherscan-compatible API endpoint, if known
+		GenesisTime uint64 `toml:"genesis_time"` // L1 execution-layer genesis timestamp
 	} `toml:"l1"`
 	Publisher struct {
-		SuperblockContract string   `toml:"superblock_contract"`
-		DisputeGameFactory string   `toml:"dispute_game_factory"`
+		SuperblockContract Address  `toml:"superblock_contract"`
+		DisputeGameFactory Address  `toml:"dispute_game_factory"`
 		AuthPubkeys        []string `toml:"auth_pubkeys"`
 	} `toml:"publisher"`
+	Lifecycle Lifecycle `toml:"lifecycle"`
+	// Placeholders lists address fields ("publisher.superblock_contract")
+	// known to hold a zero or placeholder value; see Registry.Validate.
+	Placeholders []string `toml:"placeholders"`
+	// Extra is the reserved [extra] table for custom metadata; see
+	// Network.DecodeExtra for typed access.
+	Extra map[string]any `toml:"extra"`
 }
 
-// ListNetworks lists all available networks as handles.
-func (r Registry) ListNetworks() ([]Network, error) {
+// ListNetworks lists all available networks as handles. A registry with the
+// devnet guard omits devnet networks; opts filter by lifecycle status.
+func (r Registry) ListNetworks(opts ...ListOption) ([]Network, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	o := newListOptions(opts)
 	entries, err := fs.ReadDir(r.fs, "networks")
 	if err != nil {
 		return nil, fmt.Errorf("list networks: %w", err)
@@ -115,17 +211,63 @@ func (r Registry) ListNetworks() ([]Network, error) {
 	sort.Strings(slugs)
 	out := make([]Network, 0, len(slugs))
 	for _, s := range slugs {
-		out = append(out, Network{slug: s, r: r})
+		if !r.visible(s) {
+			continue
+		}
+		if err := r.checkGuard(s); errors.Is(err, ErrDevnetForbidden) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		n := Network{slug: s, r: r}
+		if o.filtering() {
+			l, err := n.Lifecycle()
+			if err != nil {
+				return nil, err
+			}
+			if !o.keep(l) {
+				continue
+			}
+		}
+		out = append(out, n)
 	}
 	return out, nil
 }
 
-// GetNetworkBySlug returns a handle if networks/<slug> exists.
+// GetNetworkBySlug returns a handle if networks/<slug> exists or a network
+// lists slug in its aliases; Alias reports the latter. A registry with the
+// devnet guard returns ErrDevnetForbidden for devnet networks.
 func (r Registry) GetNetworkBySlug(slug string) (Network, error) {
+	if err := r.Err(); err != nil {
+		return Network{}, err
+	}
+	slug, err := r.cleanSlug(slug)
+	if err != nil {
+		return Network{}, err
+	}
+	var alias string
 	if _, err := fs.ReadDir(r.fs, path.Join("networks", slug)); err != nil {
-		return Network{}, fmt.Errorf("%w: %s", ErrNetworkNotFound, slug)
+		canonical, aerr := r.networkByAlias(slug)
+		if aerr != nil {
+			return Network{}, aerr
+		}
+		if canonical == "" {
+			if r.embedded && slices.Contains(assets.OmittedNetworks, slug) {
+				return Network{}, r.networkNotFound(slug, "dev networks not embedded in this build")
+			}
+			return Network{}, r.networkNotFound(slug, "")
+		}
+		alias, slug = slug, canonical
+	}
+	if !r.visible(slug) {
+		return Network{}, &NotFoundError{Kind: KindNetwork, Key: slug, Reason: "excluded from this registry"}
 	}
-	return Network{slug: slug, r: r}, nil
+	if err := r.checkGuard(slug); err != nil {
+		return Network{}, err
+	}
+	n := Network{slug: slug, r: r, alias: alias}
+	r.notifyNetwork(n)
+	return n, nil
 }
 
 // GetNetworkById returns the first network whose L1.ChainID matches.
@@ -140,17 +282,20 @@ func (r Registry) GetNetworkById(l1ChainId uint64) (Network, error) {
 			return Network{}, err
 		}
 		if cfg.L1.ChainID == l1ChainId {
+			r.notifyNetwork(n)
 			return n, nil
 		}
 	}
-	return Network{}, ErrNetworkNotFound
+	return Network{}, &NotFoundError{Kind: KindNetwork, Key: strconv.FormatUint(l1ChainId, 10)}
 }
 
-// ListChains returns chain handles in this network.
-func (n Network) ListChains() ([]Chain, error) {
+// ListChains returns chain handles in this network; opts filter by
+// lifecycle status.
+func (n Network) ListChains(opts ...ListOption) ([]Chain, error) {
+	o := newListOptions(opts)
 	entries, err := fs.ReadDir(n.r.fs, path.Join("networks", n.slug))
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, n.slug)
+		return nil, &NotFoundError{Kind: KindNetwork, Key: n.slug}
 	}
 	slugs := make([]string, 0, len(entries))
 	for _, e := range entries {
@@ -166,22 +311,44 @@ func (n Network) ListChains() ([]Chain, error) {
 	sort.Strings(slugs)
 	out := make([]Chain, 0, len(slugs))
 	for _, s := range slugs {
-		out = append(out, Chain{slug: s, n: n})
+		c := Chain{slug: s, n: n}
+		if o.filtering() {
+			l, err := c.Lifecycle()
+			if err != nil {
+				return nil, err
+			}
+			if !o.keep(l) {
+				continue
+			}
+		}
+		out = append(out, c)
 	}
 	return out, nil
 }
 
-// GetChainBySlug returns a chain handle if <slug>.toml exists.
+// GetChainBySlug returns a chain handle if <slug>.toml exists or a chain in
+// this network lists slug in its aliases; Alias reports the latter.
 func (n Network) GetChainBySlug(slug string) (Chain, error) {
-	s := strings.TrimSpace(slug)
-	if s == "" {
-		return Chain{}, errors.New("empty chain slug")
+	s, err := n.r.cleanSlug(slug)
+	if err != nil {
+		return Chain{}, err
+	}
+	c := Chain{slug: s, n: n}
+	if !n.chainExists(s) {
+		canonical, err := n.chainByAlias(s)
+		if err != nil {
+			return Chain{}, err
+		}
+		if canonical == "" {
+			return Chain{}, n.chainNotFound(s)
+		}
+		c.slug = canonical
 	}
-	// existence check only
-	if _, err := fs.ReadFile(n.r.fs, path.Join("networks", n.slug, s+".toml")); err != nil {
-		return Chain{}, fmt.Errorf("%w: %s/%s", ErrChainNotFound, n.slug, s)
+	if c.slug != s || n.alias != "" {
+		c.alias = n.requested() + "/" + s
 	}
-	return Chain{slug: s, n: n}, nil
+	n.r.notifyChain(c)
+	return c, nil
 }
 
 // GetChainById returns the first chain in this network whose ChainID matches.
@@ -196,21 +363,23 @@ func (n Network) GetChainById(l2ChainId uint64) (Chain, error) {
 			return Chain{}, err
 		}
 		if cfg.ChainID == l2ChainId {
+			n.r.notifyChain(ch)
 			return ch, nil
 		}
 	}
-	return Chain{}, ErrChainNotFound
+	return Chain{}, &NotFoundError{Kind: KindChain, Key: strconv.FormatUint(l2ChainId, 10), Network: n.slug}
 }
 
-// ListChains returns all chain handles across all networks.
-func (r Registry) ListChains() ([]Chain, error) {
-	nets, err := r.ListNetworks()
+// ListChains returns all chain handles across all networks. opts apply to
+// both networks and chains, so chains of an excluded network are omitted.
+func (r Registry) ListChains(opts ...ListOption) ([]Chain, error) {
+	nets, err := r.ListNetworks(opts...)
 	if err != nil {
 		return nil, err
 	}
 	var out []Chain
 	for _, n := range nets {
-		cs, err := n.ListChains()
+		cs, err := n.ListChains(opts...)
 		if err != nil {
 			return nil, err
 		}
@@ -219,7 +388,8 @@ func (r Registry) ListChains() ([]Chain, error) {
 	return out, nil
 }
 
-// GetChainByIdentifier returns a chain handle for "<network>/<slug>".
+// GetChainByIdentifier returns a chain handle for "<network>/<slug>". Either
+// part may be an alias; the handle is canonical and Alias reports the input.
 func (r Registry) GetChainByIdentifier(identifier string) (Chain, error) {
 	s := strings.TrimSpace(identifier)
 	if s == "" {
@@ -253,36 +423,35 @@ func (r Registry) GetChainById(l2ChainId uint64) (Chain, error) {
 			return Chain{}, err
 		}
 	}
-	return Chain{}, ErrChainNotFound
+	return Chain{}, &NotFoundError{Kind: KindChain, Key: strconv.FormatUint(l2ChainId, 10)}
 }
 
 // LoadConfig decodes networks/<network>/<slug>.toml for this chain.
 func (c Chain) LoadConfig() (ChainConfig, error) {
-	s := strings.TrimSpace(c.slug)
-	if s == "" {
-		return ChainConfig{}, errors.New("empty chain slug")
+	var cfg ChainConfig
+	md, err := c.decode(&cfg)
+	if err == nil && c.n.r.strict() {
+		if err = undecoded(md.Undecoded()); err != nil {
+			err = fmt.Errorf("decode %s: %w", c.configPath(), err)
+		}
 	}
-	p := path.Join("networks", c.n.slug, s+".toml")
-	b, err := fs.ReadFile(c.n.r.fs, p)
 	if err != nil {
-		return ChainConfig{}, fmt.Errorf("%w: %s/%s", ErrChainNotFound, c.n.slug, s)
-	}
-	var cfg ChainConfig
-	if _, err := toml.Decode(string(b), &cfg); err != nil {
-		return ChainConfig{}, fmt.Errorf("decode %s: %w", p, err)
+		return ChainConfig{}, err
 	}
 	return cfg, nil
 }
 
 // LoadConfig decodes networks/<slug>/compose.toml for this network.
 func (n Network) LoadConfig() (NetworkConfig, error) {
-	b, err := fs.ReadFile(n.r.fs, path.Join("networks", n.slug, "compose.toml"))
-	if err != nil {
-		return NetworkConfig{}, fmt.Errorf("read compose.toml for %s: %w", n.slug, err)
-	}
 	var cfg NetworkConfig
-	if _, err := toml.Decode(string(b), &cfg); err != nil {
-		return NetworkConfig{}, fmt.Errorf("decode compose.toml for %s: %w", n.slug, err)
+	md, err := n.decode(&cfg)
+	if err == nil && n.r.strict() {
+		if err = undecoded(md.Undecoded()); err != nil {
+			err = fmt.Errorf("decode compose.toml for %s: %w", n.slug, err)
+		}
+	}
+	if err != nil {
+		return NetworkConfig{}, err
 	}
 	return cfg, nil
 }
@@ -5,6 +5,17 @@ import (
 	"testing"
 )
 
+// dataRegistry returns the unguarded registry of ../data. Tests that need
+// devnets use it rather than New, whose contents depend on build tags.
+func dataRegistry(t *testing.T) Registry {
+	t.Helper()
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir(../data) error: %v", err)
+	}
+	return r
+}
+
 func TestGetNetworks_FindsHoodi(t *testing.T) {
 	r := New()
 	nets, err := r.ListNetworks()
@@ -27,7 +38,7 @@ func TestGetNetworks_FindsHoodi(t *testing.T) {
 }
 
 func TestGetNetworkBySlug(t *testing.T) {
-	r := New()
+	r := dataRegistry(t)
 	hoodi, err := r.GetNetworkBySlug("hoodi-dev")
 	if err != nil {
 		t.Fatalf("GetNetworkBySlug() error: %v", err)
@@ -39,10 +50,13 @@ func TestGetNetworkBySlug(t *testing.T) {
 	if ncfg.Name != "hoodi-dev" {
 		t.Fatalf("network name = %s, want hoodi", ncfg.Name)
 	}
+	if ncfg.L1.GenesisTime != 1742212800 {
+		t.Fatalf("l1 genesis_time = %d, want 1742212800", ncfg.L1.GenesisTime)
+	}
 }
 
 func TestGetChains_AndLookups(t *testing.T) {
-	r := New()
+	r := dataRegistry(t)
 	hoodi, err := r.GetNetworkBySlug("hoodi-dev")
 	if err != nil {
 		t.Fatalf("GetNetworkBySlug() error: %v", err)
@@ -87,7 +101,7 @@ func TestGetChains_AndLookups(t *testing.T) {
 }
 
 func TestGetChainByIdentifier(t *testing.T) {
-	r := New()
+	r := dataRegistry(t)
 	c, err := r.GetChainByIdentifier("hoodi-dev/rollup-a")
 	if err != nil {
 		t.Fatalf("GetChainByIdentifier error: %v", err)
@@ -102,7 +116,7 @@ func TestGetChainByIdentifier(t *testing.T) {
 }
 
 func TestRegistry_ListChains_And_GetChainById(t *testing.T) {
-	r := New()
+	r := dataRegistry(t)
 	all, err := r.ListChains()
 	if err != nil {
 		t.Fatalf("ListChains() error: %v", err)
@@ -143,7 +157,7 @@ func TestNewFromDir_Validation(t *testing.T) {
 }
 
 func TestErrors_NotFound(t *testing.T) {
-	r := New()
+	r := dataRegistry(t)
 	if _, err := r.GetNetworkBySlug("nope"); err == nil || !errors.Is(err, ErrNetworkNotFound) {
 		t.Fatalf("expected ErrNetworkNotFound, got %v", err)
 	}
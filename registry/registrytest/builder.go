@@ -0,0 +1,206 @@
+package registrytest
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"testing"
+	"testing/fstest"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/compose-network/registry/registry"
+)
+
+// Builder assembles an in-memory registry without touching the disk:
+//
+//	r := registrytest.NewBuilder().
+//		Network("testnet", 560048).
+//		Chain("rollup-a", 11113).Genesis(genesisJSON).
+//		Chain("rollup-b", 22224).
+//		MustBuild(t)
+//
+// Configs are written as TOML from registry.NetworkConfig and
+// registry.ChainConfig values, so everything the registry decodes can be
+// set with Configure. Chains pay gas in Ether unless Configure sets
+// another NativeCurrency.
+type Builder struct {
+	nets  []*NetworkBuilder
+	files fstest.MapFS
+}
+
+// NewBuilder returns an empty Builder.
+func NewBuilder() *Builder { return &Builder{files: fstest.MapFS{}} }
+
+// Network adds a network with the given L1 chain id, or returns the one
+// already added under slug. Its environment defaults to testnet; see
+// NetworkBuilder.Environment.
+func (b *Builder) Network(slug string, l1ChainID uint64) *NetworkBuilder {
+	for _, n := range b.nets {
+		if n.slug == slug {
+			return n
+		}
+	}
+	n := &NetworkBuilder{Builder: b, slug: slug}
+	n.cfg.Name = slug
+	n.cfg.Environment = registry.EnvTestnet
+	n.cfg.L1.ChainID = l1ChainID
+	b.nets = append(b.nets, n)
+	return n
+}
+
+// File adds a raw file at a data-relative path, e.g. a hand-written TOML
+// that the config structs cannot express. It overrides generated files.
+func (b *Builder) File(name string, data []byte) *Builder {
+	b.files[name] = &fstest.MapFile{Data: data}
+	return b
+}
+
+// FS renders the data layout.
+func (b *Builder) FS() (fstest.MapFS, error) {
+	out := fstest.MapFS{}
+	for _, n := range b.nets {
+		data, err := encode(n.cfg)
+		if err != nil {
+			return nil, fmt.Errorf("network %s: %w", n.slug, err)
+		}
+		out[path.Join("networks", n.slug, "compose.toml")] = &fstest.MapFile{Data: data}
+		for _, c := range n.chains {
+			cfg := c.cfg
+			if c.genesis != nil {
+				cfg.Genesis.File = registry.GenesisRef(c.genesis)
+				p, err := registry.GenesisBlobPath(cfg.Genesis.File)
+				if err != nil {
+					return nil, err
+				}
+				// Plain JSON is accepted under the .json.zst name.
+				out[p] = &fstest.MapFile{Data: c.genesis}
+			}
+			data, err := encode(cfg)
+			if err != nil {
+				return nil, fmt.Errorf("chain %s/%s: %w", n.slug, c.slug, err)
+			}
+			out[path.Join("networks", n.slug, c.slug+".toml")] = &fstest.MapFile{Data: data}
+		}
+	}
+	if len(b.nets) == 0 {
+		out["networks"] = &fstest.MapFile{Mode: fs.ModeDir | 0o755}
+	}
+	for name, f := range b.files {
+		out[name] = f
+	}
+	return out, nil
+}
+
+// Build returns a registry backed by FS.
+func (b *Builder) Build(opts ...registry.Option) (registry.Registry, error) {
+	fsys, err := b.FS()
+	if err != nil {
+		return registry.Registry{}, err
+	}
+	return registry.NewFromFS(fsys, opts...)
+}
+
+// MustBuild is Build that fails tb on error.
+func (b *Builder) MustBuild(tb testing.TB, opts ...registry.Option) registry.Registry {
+	tb.Helper()
+	r, err := b.Build(opts...)
+	if err != nil {
+		tb.Fatalf("registrytest: build registry: %v", err)
+	}
+	return r
+}
+
+// NetworkBuilder configures one network. It embeds the Builder, so calls
+// such as Network and MustBuild continue the chain.
+type NetworkBuilder struct {
+	*Builder
+	slug   string
+	cfg    registry.NetworkConfig
+	chains []*ChainBuilder
+}
+
+// Environment sets the network's environment.
+func (n *NetworkBuilder) Environment(env registry.Environment) *NetworkBuilder {
+	n.cfg.Environment = env
+	return n
+}
+
+// Configure edits the network config directly.
+func (n *NetworkBuilder) Configure(fn func(*registry.NetworkConfig)) *NetworkBuilder {
+	fn(&n.cfg)
+	return n
+}
+
+// Chain adds a chain to the network, or returns the one already added
+// under slug.
+func (n *NetworkBuilder) Chain(slug string, chainID uint64) *ChainBuilder {
+	for _, c := range n.chains {
+		if c.slug == slug {
+			return c
+		}
+	}
+	c := &ChainBuilder{n: n, slug: slug}
+	c.cfg.Name = slug
+	c.cfg.ChainID = chainID
+	c.cfg.NativeCurrency = registry.NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+	n.chains = append(n.chains, c)
+	return c
+}
+
+// ChainBuilder configures one chain.
+type ChainBuilder struct {
+	n       *NetworkBuilder
+	slug    string
+	cfg     registry.ChainConfig
+	genesis []byte
+}
+
+// RPC sets the chain's public_rpc.
+func (c *ChainBuilder) RPC(url string) *ChainBuilder {
+	c.cfg.PublicRPC = url
+	return c
+}
+
+// Configure edits the chain config directly.
+func (c *ChainBuilder) Configure(fn func(*registry.ChainConfig)) *ChainBuilder {
+	fn(&c.cfg)
+	return c
+}
+
+// Genesis stores raw as the chain's content-addressed genesis and points
+// [genesis].file at it.
+func (c *ChainBuilder) Genesis(raw []byte) *ChainBuilder {
+	c.genesis = bytes.Clone(raw)
+	return c
+}
+
+// Chain adds a sibling chain in the same network.
+func (c *ChainBuilder) Chain(slug string, chainID uint64) *ChainBuilder {
+	return c.n.Chain(slug, chainID)
+}
+
+// Network adds another network; see Builder.Network.
+func (c *ChainBuilder) Network(slug string, l1ChainID uint64) *NetworkBuilder {
+	return c.n.Network(slug, l1ChainID)
+}
+
+// Build builds the whole registry; see Builder.Build.
+func (c *ChainBuilder) Build(opts ...registry.Option) (registry.Registry, error) {
+	return c.n.Build(opts...)
+}
+
+// MustBuild builds the whole registry; see Builder.MustBuild.
+func (c *ChainBuilder) MustBuild(tb testing.TB, opts ...registry.Option) registry.Registry {
+	tb.Helper()
+	return c.n.MustBuild(tb, opts...)
+}
+
+func encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
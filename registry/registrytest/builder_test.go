@@ -0,0 +1,67 @@
+package registrytest
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/compose-network/registry/registry"
+)
+
+const builderGenesis = `{"config":{"chainId":11113},"timestamp":"0x10","gasLimit":"0x1c9c380","alloc":{}}`
+
+func TestBuilder(t *testing.T) {
+	r := NewBuilder().
+		Network("testnet", 560048).
+		Configure(func(c *registry.NetworkConfig) { c.L1.PublicRPC = "https://l1.example" }).
+		Chain("rollup-a", 11113).Genesis([]byte(builderGenesis)).
+		Chain("rollup-b", 22224).RPC("https://rollup-b.example").
+		Network("local-dev", 1337).Environment(registry.EnvDevnet).
+		Chain("rollup-a", 77777).
+		MustBuild(t, registry.WithStrictDecoding(), registry.WithEagerValidation())
+
+	var rd registry.Reader = r
+	c, err := rd.GetChainById(22224)
+	if err != nil {
+		t.Fatalf("GetChainById error: %v", err)
+	}
+	cfg, err := c.LoadConfig()
+	if err != nil || c.Identifier() != "testnet/rollup-b" || cfg.PublicRPC != "https://rollup-b.example" {
+		t.Fatalf("rollup-b = %s %+v (%v)", c.Identifier(), cfg, err)
+	}
+	n, err := rd.GetNetworkBySlug("testnet")
+	if err != nil {
+		t.Fatalf("GetNetworkBySlug error: %v", err)
+	}
+	if ncfg, err := n.LoadConfig(); err != nil || ncfg.L1.PublicRPC != "https://l1.example" || ncfg.Environment != registry.EnvTestnet {
+		t.Fatalf("testnet config = %+v (%v)", ncfg, err)
+	}
+	if env, _ := r.ListNetworksByEnvironment(registry.EnvDevnet); len(env) != 1 || env[0].Slug() != "local-dev" {
+		t.Fatalf("devnets = %v, want [local-dev]", env)
+	}
+
+	a, _ := rd.GetChainByIdentifier("testnet/rollup-a")
+	raw, err := a.LoadGenesis()
+	if err != nil || string(raw) != builderGenesis {
+		t.Fatalf("LoadGenesis = %s, %v", raw, err)
+	}
+	if _, err := c.LoadGenesis(); !errors.Is(err, registry.ErrGenesisNotFound) {
+		t.Fatalf("rollup-b LoadGenesis error = %v, want ErrGenesisNotFound", err)
+	}
+
+	srv := Start(t, r)
+	res, rpcErr := call(t, srv.URL("testnet/rollup-a"), "eth_chainId")
+	if rpcErr != nil {
+		t.Fatalf("eth_chainId error: %+v", rpcErr)
+	}
+	if want := strconv.Quote("0x2b69"); string(res) != want {
+		t.Fatalf("eth_chainId = %s, want %s", res, want)
+	}
+}
+
+func TestBuilder_Empty(t *testing.T) {
+	r := NewBuilder().MustBuild(t)
+	if nets, err := r.ListNetworks(); err != nil || len(nets) != 0 {
+		t.Fatalf("ListNetworks = %v, %v; want none", nets, err)
+	}
+}
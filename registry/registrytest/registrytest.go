@@ -0,0 +1,394 @@
+// Package registrytest provides in-memory registries and in-process
+// JSON-RPC endpoints for registry chains, for tests that should not depend
+// on the embedded data or dial a real public_rpc.
+//
+// NewBuilder assembles a registry from a few lines of Go; see Builder.
+//
+// Start serves every chain (and each network's L1) of a registry from an
+// httptest server and returns a Registry overlay whose public_rpc URLs point
+// at those servers:
+//
+//	srv := registrytest.Start(t, registry.New())
+//	c, _ := srv.Registry.GetChainByIdentifier("hoodi-dev/rollup-a")
+//	cfg, _ := c.LoadConfig() // cfg.PublicRPC is srv.URL("hoodi-dev/rollup-a")
+//
+// Chain servers answer eth_chainId, net_version, eth_blockNumber,
+// eth_getBlockByNumber for the genesis block and eth_getCode/eth_getBalance
+// for genesis alloc accounts. L1 servers answer eth_chainId and net_version.
+// The genesis block is built from the genesis JSON fields; its hash and
+// state root are not computed and are returned as zero unless the genesis
+// file carries them.
+package registrytest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/compose-network/registry/internal/tomledit"
+	"github.com/compose-network/registry/registry"
+)
+
+// Servers holds the running endpoints. They are closed by tb.Cleanup.
+type Servers struct {
+	// Registry is the overlay: the base registry with every public_rpc
+	// (chain TOMLs and [l1] in compose.toml) replaced by a server URL.
+	Registry registry.Registry
+
+	chains map[string]*httptest.Server // by identifier
+	l1     map[string]*httptest.Server // by network slug
+}
+
+// URL returns the endpoint of the chain "<network>/<slug>", or "" if the
+// chain is not served.
+func (s *Servers) URL(identifier string) string {
+	if srv, ok := s.chains[identifier]; ok {
+		return srv.URL
+	}
+	return ""
+}
+
+// L1URL returns the L1 endpoint of a network, or "" if it is not served.
+func (s *Servers) L1URL(network string) string {
+	if srv, ok := s.l1[network]; ok {
+		return srv.URL
+	}
+	return ""
+}
+
+// Start serves every chain and L1 that base lists. Chains without a genesis
+// (missing or not embedded) still answer chain id queries; genesis methods
+// then return a JSON-RPC error. The overlay is built with base.Options(), so
+// networks base hides stay hidden.
+func Start(tb testing.TB, base registry.Registry) *Servers {
+	tb.Helper()
+	s := &Servers{
+		chains: make(map[string]*httptest.Server),
+		l1:     make(map[string]*httptest.Server),
+	}
+	overrides := fstest.MapFS{}
+	nets, err := base.ListNetworks()
+	if err != nil {
+		tb.Fatalf("registrytest: %v", err)
+	}
+	for _, n := range nets {
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			tb.Fatalf("registrytest: %v", err)
+		}
+		srv := serve(tb, &node{chainID: ncfg.L1.ChainID})
+		s.l1[n.Slug()] = srv
+		if err := override(base.FS(), overrides, path.Join("networks", n.Slug(), "compose.toml"), "l1", srv.URL); err != nil {
+			tb.Fatalf("registrytest: %v", err)
+		}
+
+		chains, err := n.ListChains()
+		if err != nil {
+			tb.Fatalf("registrytest: %v", err)
+		}
+		for _, c := range chains {
+			cfg, err := c.LoadConfig()
+			if err != nil {
+				tb.Fatalf("registrytest: %v", err)
+			}
+			nd := &node{chainID: cfg.ChainID}
+			raw, err := c.LoadGenesis()
+			switch {
+			case err == nil:
+				if nd.genesis, err = parseGenesis(raw); err != nil {
+					tb.Fatalf("registrytest: %s: %v", c.Identifier(), err)
+				}
+			case errors.Is(err, registry.ErrGenesisNotFound), errors.Is(err, registry.ErrGenesisNotEmbedded):
+			default:
+				tb.Fatalf("registrytest: %v", err)
+			}
+			srv := serve(tb, nd)
+			s.chains[c.Identifier()] = srv
+			if err := override(base.FS(), overrides, path.Join("networks", n.Slug(), c.Slug()+".toml"), "", srv.URL); err != nil {
+				tb.Fatalf("registrytest: %v", err)
+			}
+		}
+	}
+	// With base's options (devnet guard, network filters, strictness,
+	// embedded genesis state) the overlay lists exactly the networks served
+	// above, and hidden ones fail as they do in base.
+	s.Registry, err = registry.NewFromFS(overlay{base: base.FS(), files: overrides}, base.Options()...)
+	if err != nil {
+		tb.Fatalf("registrytest: %v", err)
+	}
+	return s
+}
+
+func serve(tb testing.TB, nd *node) *httptest.Server {
+	srv := httptest.NewServer(nd)
+	tb.Cleanup(srv.Close)
+	return srv
+}
+
+// overlay serves files from files in place of the same paths in base.
+type overlay struct {
+	base  fs.FS
+	files fstest.MapFS
+}
+
+func (o overlay) Open(name string) (fs.File, error) {
+	if _, ok := o.files[name]; ok {
+		return o.files.Open(name)
+	}
+	return o.base.Open(name)
+}
+
+// override copies the TOML file at p from fsys into files with public_rpc
+// in table (""= top level) set to url.
+func override(fsys fs.FS, files fstest.MapFS, p, table, url string) error {
+	b, err := fs.ReadFile(fsys, p)
+	if err != nil {
+		return err
+	}
+	if _, ok := tomledit.Get(string(b), table, "public_rpc"); !ok {
+		return fmt.Errorf("%s: no public_rpc to override", p)
+	}
+	text, _ := tomledit.Set(string(b), table, "public_rpc", strconv.Quote(url))
+	files[p] = &fstest.MapFile{Data: []byte(text)}
+	return nil
+}
+
+// genesis is the subset of a genesis file served by a chain node.
+type genesis struct {
+	header map[string]any
+	alloc  map[string]account // by lowercase unprefixed address
+}
+
+type account struct {
+	Code    string          `json:"code"`
+	Balance json.RawMessage `json:"balance"`
+}
+
+// parseGenesis builds the genesis block header fields and alloc index.
+func parseGenesis(raw []byte) (*genesis, error) {
+	var g struct {
+		Nonce         json.RawMessage    `json:"nonce"`
+		Timestamp     json.RawMessage    `json:"timestamp"`
+		ExtraData     string             `json:"extraData"`
+		GasLimit      json.RawMessage    `json:"gasLimit"`
+		Difficulty    json.RawMessage    `json:"difficulty"`
+		MixHash       string             `json:"mixHash"`
+		Coinbase      string             `json:"coinbase"`
+		BaseFeePerGas json.RawMessage    `json:"baseFeePerGas"`
+		ParentHash    string             `json:"parentHash"`
+		StateRoot     string             `json:"stateRoot"`
+		Hash          string             `json:"hash"`
+		Alloc         map[string]account `json:"alloc"`
+	}
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return nil, fmt.Errorf("decode genesis: %w", err)
+	}
+	const (
+		zeroHash       = "0x0000000000000000000000000000000000000000000000000000000000000000"
+		emptyRoot      = "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421"
+		emptyUncleHash = "0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"
+	)
+	quantities := map[string]json.RawMessage{
+		"nonce": g.Nonce, "timestamp": g.Timestamp, "gasLimit": g.GasLimit, "difficulty": g.Difficulty,
+	}
+	h := map[string]any{
+		"number":           "0x0",
+		"hash":             orDefault(g.Hash, zeroHash),
+		"parentHash":       orDefault(g.ParentHash, zeroHash),
+		"stateRoot":        orDefault(g.StateRoot, zeroHash),
+		"transactionsRoot": emptyRoot,
+		"receiptsRoot":     emptyRoot,
+		"sha3Uncles":       emptyUncleHash,
+		"logsBloom":        "0x" + strings.Repeat("0", 512),
+		"miner":            orDefault(g.Coinbase, "0x0000000000000000000000000000000000000000"),
+		"mixHash":          orDefault(g.MixHash, zeroHash),
+		"extraData":        orDefault(g.ExtraData, "0x"),
+		"gasUsed":          "0x0",
+		"size":             "0x0",
+		"totalDifficulty":  "0x0",
+		"transactions":     []any{},
+		"uncles":           []any{},
+	}
+	for k, v := range quantities {
+		q, err := quantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("genesis %s: %w", k, err)
+		}
+		h[k] = q
+	}
+	h["nonce"] = fmt.Sprintf("0x%016s", strings.TrimPrefix(h["nonce"].(string), "0x"))
+	if len(g.BaseFeePerGas) > 0 {
+		q, err := quantity(g.BaseFeePerGas)
+		if err != nil {
+			return nil, fmt.Errorf("genesis baseFeePerGas: %w", err)
+		}
+		h["baseFeePerGas"] = q
+	}
+	alloc := make(map[string]account, len(g.Alloc))
+	for addr, acct := range g.Alloc {
+		alloc[addrKey(addr)] = acct
+	}
+	return &genesis{header: h, alloc: alloc}, nil
+}
+
+// quantity converts a genesis number (hex string, decimal string or JSON
+// number) to a JSON-RPC hex quantity. Absent values are zero.
+func quantity(v json.RawMessage) (string, error) {
+	s := strings.Trim(strings.TrimSpace(string(v)), `"`)
+	if s == "" || s == "null" {
+		return "0x0", nil
+	}
+	n, ok := new(big.Int), false
+	if h, isHex := strings.CutPrefix(s, "0x"); isHex {
+		if h == "" {
+			return "0x0", nil
+		}
+		_, ok = n.SetString(h, 16)
+	} else {
+		_, ok = n.SetString(s, 10)
+	}
+	if !ok {
+		return "", fmt.Errorf("invalid number %s", v)
+	}
+	return "0x" + n.Text(16), nil
+}
+
+func addrKey(addr string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(addr, "0x"), "0X"))
+}
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
+
+// node is a JSON-RPC handler for one chain; genesis is nil for L1 nodes and
+// chains without a genesis file.
+type node struct {
+	chainID uint64
+	genesis *genesis
+}
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+func (nd *node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "JSON-RPC requires POST", http.StatusMethodNotAllowed)
+		return
+	}
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{-32700, "parse error"}})
+		return
+	}
+	if b := strings.TrimSpace(string(body)); strings.HasPrefix(b, "[") {
+		var reqs []rpcRequest
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{-32600, "invalid request"}})
+			return
+		}
+		resps := make([]rpcResponse, 0, len(reqs))
+		for _, req := range reqs {
+			resps = append(resps, nd.handle(req))
+		}
+		writeJSON(w, resps)
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{-32600, "invalid request"}})
+		return
+	}
+	writeJSON(w, nd.handle(req))
+}
+
+func (nd *node) handle(req rpcRequest) rpcResponse {
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	result, rerr := nd.call(req.Method, req.Params)
+	if rerr != nil {
+		resp.Error = rerr
+	} else {
+		// A nil result must still be sent as "result": null.
+		resp.Result = nullable{result}
+	}
+	return resp
+}
+
+// nullable marshals a nil value as JSON null so omitempty keeps "result".
+type nullable struct{ v any }
+
+func (n nullable) MarshalJSON() ([]byte, error) { return json.Marshal(n.v) }
+
+func (nd *node) call(method string, params []json.RawMessage) (any, *rpcError) {
+	switch method {
+	case "eth_chainId":
+		return "0x" + strconv.FormatUint(nd.chainID, 16), nil
+	case "net_version":
+		return strconv.FormatUint(nd.chainID, 10), nil
+	}
+	if nd.genesis == nil {
+		return nil, &rpcError{-32601, fmt.Sprintf("method %s not available (no genesis)", method)}
+	}
+	switch method {
+	case "eth_blockNumber":
+		return "0x0", nil
+	case "eth_getBlockByNumber":
+		var tag string
+		if len(params) < 1 || json.Unmarshal(params[0], &tag) != nil {
+			return nil, &rpcError{-32602, "invalid params"}
+		}
+		switch tag {
+		case "0x0", "earliest", "latest", "safe", "finalized", "pending":
+			return nd.genesis.header, nil
+		}
+		return nil, nil
+	case "eth_getCode", "eth_getBalance":
+		var addr string
+		if len(params) < 1 || json.Unmarshal(params[0], &addr) != nil {
+			return nil, &rpcError{-32602, "invalid params"}
+		}
+		acct := nd.genesis.alloc[addrKey(addr)]
+		if method == "eth_getCode" {
+			return orDefault(acct.Code, "0x"), nil
+		}
+		q, err := quantity(acct.Balance)
+		if err != nil {
+			return nil, &rpcError{-32000, err.Error()}
+		}
+		return q, nil
+	}
+	return nil, &rpcError{-32601, fmt.Sprintf("method %s not found", method)}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,184 @@
+package registrytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/compose-network/registry/registry"
+)
+
+func call(t *testing.T, url, method string, params ...any) (json.RawMessage, *rpcError) {
+	t.Helper()
+	if params == nil {
+		params = []any{}
+	}
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	var out struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("%s: decode: %v", method, err)
+	}
+	return out.Result, out.Error
+}
+
+const startGenesis = `{
+  "config": {"chainId": 33333},
+  "timestamp": "0x692030a0",
+  "gasLimit": "0x1c9c380",
+  "baseFeePerGas": "0x3b9aca00",
+  "alloc": {
+    "42000000000000000000000000000000000003a4": {"code": "0x6080604052", "balance": "0x0"}
+  }
+}`
+
+// startRegistry is the fixture served by the Start tests.
+func startRegistry(t *testing.T) registry.Registry {
+	t.Helper()
+	return NewBuilder().
+		Network("testnet", 11155111).
+		Configure(func(c *registry.NetworkConfig) { c.L1.PublicRPC = "https://l1.example" }).
+		Chain("rollup-a", 33333).RPC("https://rollup-a.example").Genesis([]byte(startGenesis)).
+		Configure(func(c *registry.ChainConfig) {
+			c.Addresses.Mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+		}).
+		MustBuild(t)
+}
+
+func TestStart_OverlayPointsAtServers(t *testing.T) {
+	srv := Start(t, startRegistry(t))
+	c, err := srv.Registry.GetChainByIdentifier("testnet/rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier() error: %v", err)
+	}
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		t.Fatalf("LoadConfig() error: %v", err)
+	}
+	if cfg.PublicRPC == "" || cfg.PublicRPC != srv.URL("testnet/rollup-a") {
+		t.Fatalf("public_rpc = %q, want server URL %q", cfg.PublicRPC, srv.URL("testnet/rollup-a"))
+	}
+	if cfg.ChainID != 33333 || cfg.Addresses.Mailbox == "" {
+		t.Fatalf("overlay lost chain fields: %+v", cfg)
+	}
+	if _, err := c.LoadGenesis(); err != nil {
+		t.Fatalf("LoadGenesis() through overlay error: %v", err)
+	}
+	ncfg, err := c.Network().LoadConfig()
+	if err != nil {
+		t.Fatalf("network LoadConfig() error: %v", err)
+	}
+	if ncfg.L1.PublicRPC != srv.L1URL("testnet") {
+		t.Fatalf("l1 public_rpc = %q, want %q", ncfg.L1.PublicRPC, srv.L1URL("testnet"))
+	}
+	res, rerr := call(t, ncfg.L1.PublicRPC, "eth_chainId")
+	if rerr != nil || string(res) != strconv.Quote("0x"+strconv.FormatUint(ncfg.L1.ChainID, 16)) {
+		t.Fatalf("L1 eth_chainId = %s, %v", res, rerr)
+	}
+}
+
+func TestStart_ChainMethods(t *testing.T) {
+	srv := Start(t, startRegistry(t))
+	url := srv.URL("testnet/rollup-a")
+
+	if res, rerr := call(t, url, "eth_chainId"); rerr != nil || string(res) != `"0x8235"` {
+		t.Fatalf("eth_chainId = %s, %v; want 0x8235", res, rerr)
+	}
+	if res, rerr := call(t, url, "net_version"); rerr != nil || string(res) != `"33333"` {
+		t.Fatalf("net_version = %s, %v; want 33333", res, rerr)
+	}
+
+	res, rerr := call(t, url, "eth_getBlockByNumber", "0x0", false)
+	if rerr != nil {
+		t.Fatalf("eth_getBlockByNumber error: %v", rerr)
+	}
+	var block map[string]any
+	if err := json.Unmarshal(res, &block); err != nil {
+		t.Fatalf("decode block: %v", err)
+	}
+	if block["number"] != "0x0" || block["timestamp"] != "0x692030a0" || block["baseFeePerGas"] != "0x3b9aca00" {
+		t.Fatalf("genesis block = %v", block)
+	}
+	if res, _ := call(t, url, "eth_getBlockByNumber", "0x1", false); string(res) != "null" {
+		t.Fatalf("block 1 = %s, want null", res)
+	}
+
+	res, rerr = call(t, url, "eth_getCode", "0x42000000000000000000000000000000000003A4", "latest")
+	var code string
+	if rerr != nil || json.Unmarshal(res, &code) != nil || !strings.HasPrefix(code, "0x") || len(code) <= 2 {
+		t.Fatalf("eth_getCode(predeploy) = %s, %v", res, rerr)
+	}
+	if res, _ := call(t, url, "eth_getCode", "0x00000000000000000000000000000000000000ff", "latest"); string(res) != `"0x"` {
+		t.Fatalf("eth_getCode(empty) = %s, want 0x", res)
+	}
+	if _, rerr := call(t, url, "eth_sendRawTransaction", "0x00"); rerr == nil || rerr.Code != -32601 {
+		t.Fatalf("unknown method error = %v, want -32601", rerr)
+	}
+}
+
+// TestStart_KeepsBaseOptions also runs with -tags registry_production (see
+// Makefile), where New has the devnet guard on: devnets base hides must not
+// reappear, unserved, in the overlay.
+func TestStart_KeepsBaseOptions(t *testing.T) {
+	base := registry.New()
+	srv := Start(t, base)
+	_, baseErr := base.GetNetworkBySlug("hoodi-dev")
+	_, err := srv.Registry.GetNetworkBySlug("hoodi-dev")
+	if errors.Is(baseErr, registry.ErrDevnetForbidden) != errors.Is(err, registry.ErrDevnetForbidden) {
+		t.Fatalf("overlay GetNetworkBySlug(hoodi-dev) error = %v, base error = %v", err, baseErr)
+	}
+
+	want, err := base.ListChains()
+	if err != nil {
+		t.Fatalf("base ListChains error: %v", err)
+	}
+	got, err := srv.Registry.ListChains()
+	if err != nil {
+		t.Fatalf("overlay ListChains error: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("overlay lists %d chains, base %d", len(got), len(want))
+	}
+	for i, c := range got {
+		if c.Identifier() != want[i].Identifier() {
+			t.Fatalf("overlay chain %d = %s, want %s", i, c.Identifier(), want[i].Identifier())
+		}
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) error: %v", c.Identifier(), err)
+		}
+		if cfg.PublicRPC == "" || cfg.PublicRPC != srv.URL(c.Identifier()) {
+			t.Fatalf("%s public_rpc = %q, want its server %q", c.Identifier(), cfg.PublicRPC, srv.URL(c.Identifier()))
+		}
+		_, baseErr := want[i].LoadGenesis()
+		_, err = c.LoadGenesis()
+		if errors.Is(baseErr, registry.ErrGenesisNotEmbedded) != errors.Is(err, registry.ErrGenesisNotEmbedded) {
+			t.Fatalf("%s overlay LoadGenesis error = %v, base error = %v", c.Identifier(), err, baseErr)
+		}
+	}
+}
+
+func TestStart_Filtered(t *testing.T) {
+	base := NewBuilder().
+		Network("testnet", 11155111).Chain("rollup-a", 33333).
+		Network("other", 1).Chain("rollup-b", 44444).
+		MustBuild(t, registry.WithoutNetworks("other"))
+	srv := Start(t, base)
+	if srv.URL("other/rollup-b") != "" {
+		t.Fatal("Start served a chain of an excluded network")
+	}
+	if _, err := srv.Registry.GetChainByIdentifier("other/rollup-b"); !errors.Is(err, registry.ErrNetworkNotFound) {
+		t.Fatalf("overlay GetChainByIdentifier(other/rollup-b) error = %v, want ErrNetworkNotFound", err)
+	}
+}
@@ -0,0 +1,243 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrAmbiguousSelector is matched by the *AmbiguousError that Resolve
+// returns when a selector names more than one network or chain.
+var ErrAmbiguousSelector = errors.New("ambiguous selector")
+
+// AmbiguousError lists what an ambiguous selector matched: network slugs
+// and chain identifiers, in registry order.
+type AmbiguousError struct {
+	Selector   string
+	Candidates []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%v: %q matches %s", ErrAmbiguousSelector, e.Selector, strings.Join(e.Candidates, ", "))
+}
+
+// Is reports whether target is ErrAmbiguousSelector.
+func (e *AmbiguousError) Is(target error) bool { return target == ErrAmbiguousSelector }
+
+// Resolved is the result of Resolve: a chain, or a network when the
+// selector named one. Network is set in both cases.
+type Resolved struct {
+	Network Network
+	Chain   Chain
+}
+
+// IsChain reports whether the selector resolved to a chain.
+func (res Resolved) IsChain() bool { return res.Chain.slug != "" }
+
+// caip2Namespace is the CAIP-2 namespace of EVM chains.
+const caip2Namespace = "eip155"
+
+// Resolve interprets a user-supplied selector:
+//
+//   - "<network>/<slug>": a chain identifier, as GetChainByIdentifier
+//   - "11113", "0x2b69" or "eip155:11113": a chain id, which must belong to
+//     exactly one L2 chain; an id that is no L2 chain is matched against the
+//     networks' L1 chain ids
+//   - "rollup-a" or "hoodi": a bare network or chain slug (or alias), which
+//     must match exactly one network or chain
+//
+// Selectors that match several entries return an *AmbiguousError listing
+// the candidates.
+func (r Registry) Resolve(selector string) (Resolved, error) {
+	s := strings.TrimSpace(selector)
+	switch {
+	case s == "":
+		return Resolved{}, errors.New("empty selector")
+	case strings.Contains(s, "/"):
+		c, err := r.GetChainByIdentifier(s)
+		if err != nil {
+			return Resolved{}, err
+		}
+		return Resolved{Network: c.n, Chain: c}, nil
+	}
+	if ref, ok := strings.CutPrefix(s, caip2Namespace+":"); ok {
+		id, err := strconv.ParseUint(ref, 10, 64)
+		if err != nil {
+			return Resolved{}, fmt.Errorf("invalid CAIP-2 selector %q: want %s:<decimal chain id>", selector, caip2Namespace)
+		}
+		return r.resolveID(selector, id)
+	}
+	if id, ok := parseChainID(s); ok {
+		return r.resolveID(selector, id)
+	}
+	slug, err := r.cleanSlug(s)
+	if err != nil {
+		return Resolved{}, err
+	}
+	return r.resolveSlug(selector, slug)
+}
+
+// parseChainID parses a decimal or 0x-prefixed hex chain id.
+func parseChainID(s string) (uint64, bool) {
+	var id uint64
+	var err error
+	if hex, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		id, err = strconv.ParseUint(hex, 16, 64)
+	} else {
+		id, err = strconv.ParseUint(s, 10, 64)
+	}
+	return id, err == nil
+}
+
+func (r Registry) resolveID(selector string, id uint64) (Resolved, error) {
+	chains, err := r.ListChains()
+	if err != nil {
+		return Resolved{}, err
+	}
+	var ids []string
+	var match Chain
+	for _, c := range chains {
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			return Resolved{}, err
+		}
+		if cfg.ChainID == id {
+			ids = append(ids, c.Identifier())
+			match = c
+		}
+	}
+	switch {
+	case len(ids) == 1:
+		r.notifyChain(match)
+		return Resolved{Network: match.n, Chain: match}, nil
+	case len(ids) > 1:
+		return Resolved{}, &AmbiguousError{Selector: selector, Candidates: ids}
+	}
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return Resolved{}, err
+	}
+	var matches []Network
+	for _, n := range nets {
+		cfg, err := n.LoadConfig()
+		if err != nil {
+			return Resolved{}, err
+		}
+		if cfg.L1.ChainID == id {
+			matches = append(matches, n)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Resolved{}, &NotFoundError{Kind: KindChain, Key: strconv.FormatUint(id, 10), Reason: "no chain or network has this chain id"}
+	case 1:
+		r.notifyNetwork(matches[0])
+		return Resolved{Network: matches[0]}, nil
+	}
+	cands := make([]string, 0, len(matches))
+	for _, n := range matches {
+		cands = append(cands, n.slug)
+	}
+	return Resolved{}, &AmbiguousError{Selector: selector, Candidates: cands}
+}
+
+func (r Registry) resolveSlug(selector, slug string) (Resolved, error) {
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return Resolved{}, err
+	}
+	var cands []string
+	netIdx, chainIdx := -1, -1
+	for i, n := range nets {
+		cfg, err := n.LoadConfig()
+		if err != nil {
+			return Resolved{}, err
+		}
+		if n.slug == slug || slices.Contains(cfg.Aliases, slug) {
+			cands = append(cands, n.slug)
+			netIdx = i
+		}
+		canonical := slug
+		if !n.chainExists(slug) {
+			if canonical, err = n.chainByAlias(slug); err != nil {
+				return Resolved{}, err
+			}
+		}
+		if canonical != "" {
+			cands = append(cands, n.slug+"/"+canonical)
+			chainIdx = i
+		}
+	}
+	switch {
+	case len(cands) == 0:
+		return Resolved{}, &NotFoundError{Kind: KindChain, Key: slug, Reason: "no network or chain has this slug", Suggestions: suggestSlugs(slug, nets)}
+	case len(cands) > 1:
+		return Resolved{}, &AmbiguousError{Selector: selector, Candidates: cands}
+	case netIdx >= 0:
+		n := nets[netIdx]
+		if n.slug != slug {
+			n.alias = slug
+		}
+		r.notifyNetwork(n)
+		return Resolved{Network: n}, nil
+	}
+	c, err := nets[chainIdx].GetChainBySlug(slug)
+	if err != nil {
+		return Resolved{}, err
+	}
+	return Resolved{Network: c.n, Chain: c}, nil
+}
+
+// suggestSlugs returns the network and chain slugs in nets closest to slug.
+func suggestSlugs(slug string, nets []Network) []string {
+	var names []string
+	for _, n := range nets {
+		names = append(names, n.slug)
+		chains, err := n.ListChains()
+		if err != nil {
+			continue
+		}
+		for _, c := range chains {
+			names = append(names, c.slug)
+		}
+	}
+	slices.Sort(names)
+	return suggest(slug, slices.Compact(names))
+}
+
+// CAIP2 returns the chain's CAIP-2 id, e.g. "eip155:11113".
+func (c Chain) CAIP2() (string, error) {
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return caip2(cfg.ChainID), nil
+}
+
+// CAIP10 returns the CAIP-10 account id of addr on this chain, e.g.
+// "eip155:11113:0x…".
+func (c Chain) CAIP10(addr Address) (string, error) {
+	if addr.Kind() == AddressInvalid || addr.Kind() == AddressUnset {
+		return "", fmt.Errorf("caip-10: %q is not a 0x-prefixed 20-byte address", addr)
+	}
+	id, err := c.CAIP2()
+	if err != nil {
+		return "", err
+	}
+	return id + ":" + strings.TrimSpace(string(addr)), nil
+}
+
+// CAIP2 returns the CAIP-2 id of the network's L1, e.g. "eip155:560048".
+func (n Network) CAIP2() (string, error) {
+	cfg, err := n.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return caip2(cfg.L1.ChainID), nil
+}
+
+func caip2(chainID uint64) string {
+	return caip2Namespace + ":" + strconv.FormatUint(chainID, 10)
+}
@@ -0,0 +1,108 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestResolve(t *testing.T) {
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	cases := map[string]string{
+		"hoodi/rollup-a":  "hoodi/rollup-a",
+		"11113":           "hoodi/rollup-a",
+		"0x2b69":          "hoodi/rollup-a",
+		"eip155:22224":    "hoodi/rollup-b",
+		" 33333 ":         "sepolia-dev/rollup-a",
+		"hoodi":           "hoodi",
+		"sepolia-dev":     "sepolia-dev",
+		"eip155:11155111": "sepolia-dev",
+	}
+	for sel, want := range cases {
+		res, err := r.Resolve(sel)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", sel, err)
+		}
+		got := res.Network.Slug()
+		if res.IsChain() {
+			got = res.Chain.Identifier()
+		}
+		if got != want {
+			t.Fatalf("Resolve(%q) = %s, want %s", sel, got, want)
+		}
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+
+	_, err = r.Resolve("rollup-a")
+	var amb *AmbiguousError
+	if !errors.As(err, &amb) || !errors.Is(err, ErrAmbiguousSelector) {
+		t.Fatalf("Resolve(rollup-a) error = %v, want *AmbiguousError", err)
+	}
+	if want := "hoodi/rollup-a,hoodi-dev/rollup-a,sepolia-dev/rollup-a"; strings.Join(amb.Candidates, ",") != want {
+		t.Fatalf("candidates = %v, want %s", amb.Candidates, want)
+	}
+	if _, err := r.Resolve("560048"); !errors.Is(err, ErrAmbiguousSelector) {
+		t.Fatalf("Resolve(560048) error = %v, want ambiguous (hoodi, hoodi-dev)", err)
+	}
+	for _, sel := range []string{"nope", "999999", "eip155:999999"} {
+		if _, err := r.Resolve(sel); !errors.Is(err, ErrChainNotFound) {
+			t.Fatalf("Resolve(%q) error = %v, want ErrChainNotFound", sel, err)
+		}
+	}
+	for _, sel := range []string{"", "eip155:0x2b69", "hoodi/nope"} {
+		if _, err := r.Resolve(sel); err == nil {
+			t.Fatalf("Resolve(%q) succeeded, want error", sel)
+		}
+	}
+}
+
+func TestResolve_AmbiguousL2ID(t *testing.T) {
+	r, err := NewFromFS(fstest.MapFS{
+		"networks/a/compose.toml": {Data: []byte("[l1]\nchain_id = 1\n")},
+		"networks/a/rollup.toml":  {Data: []byte("chain_id = 42\n")},
+		"networks/b/compose.toml": {Data: []byte("[l1]\nchain_id = 1\n")},
+		"networks/b/rollup.toml":  {Data: []byte("chain_id = 42\n")},
+		"networks/b/other.toml":   {Data: []byte("chain_id = 43\n")},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	_, err = r.Resolve("eip155:42")
+	var amb *AmbiguousError
+	if !errors.As(err, &amb) || strings.Join(amb.Candidates, ",") != "a/rollup,b/rollup" {
+		t.Fatalf("Resolve(eip155:42) error = %v, want ambiguous a/rollup, b/rollup", err)
+	}
+	if res, err := r.Resolve("43"); err != nil || res.Chain.Identifier() != "b/other" {
+		t.Fatalf("Resolve(43) = %v, %v; want b/other", res.Chain.Identifier(), err)
+	}
+}
+
+func TestCAIP(t *testing.T) {
+	c, err := New().GetChainByIdentifier("hoodi/rollup-a")
+	if err != nil {
+		t.Fatalf("GetChainByIdentifier error: %v", err)
+	}
+	if got, err := c.CAIP2(); err != nil || got != "eip155:11113" {
+		t.Fatalf("CAIP2() = %q, %v", got, err)
+	}
+	addr := Address("0x248721a59a2756E579026aDA017bd9B6adFe3e57")
+	if got, err := c.CAIP10(addr); err != nil || got != "eip155:11113:"+string(addr) {
+		t.Fatalf("CAIP10() = %q, %v", got, err)
+	}
+	if _, err := c.CAIP10("0x1234"); err == nil {
+		t.Fatal("CAIP10 accepted a malformed address")
+	}
+	if got, err := c.Network().CAIP2(); err != nil || got != "eip155:560048" {
+		t.Fatalf("Network.CAIP2() = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// ErrInvalidSlug is returned for network or chain slugs that are not
+// lowercase letters, digits and '-', including path-like input such as
+// "../hoodi".
+var ErrInvalidSlug = errors.New("invalid slug")
+
+// ValidateSlug checks that s is a well-formed network or chain slug:
+// lowercase ASCII letters, digits and '-', not starting with '-'.
+func ValidateSlug(s string) error {
+	switch {
+	case s == "":
+		return fmt.Errorf("%w: empty", ErrInvalidSlug)
+	case strings.ContainsAny(s, `/\`) || strings.Contains(s, ".."):
+		return fmt.Errorf("%w: %q is path-like", ErrInvalidSlug, s)
+	}
+	for i, c := range s {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' && i > 0 {
+			continue
+		}
+		return fmt.Errorf("%w: %q: want lowercase letters, digits and '-'", ErrInvalidSlug, s)
+	}
+	return nil
+}
+
+// NormalizeSlug trims surrounding whitespace and lowercases s. It does not
+// validate the result.
+func NormalizeSlug(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+
+// WithNormalizedSlugs makes lookups pass slugs and identifiers through
+// NormalizeSlug first, so "Hoodi/Rollup-A" resolves.
+func WithNormalizedSlugs() Option {
+	return func(o *options) { o.normalize = true }
+}
+
+// cleanSlug trims (and, if r normalizes slugs, lowercases) s and validates it.
+func (r Registry) cleanSlug(s string) (string, error) {
+	if r.opts().normalize {
+		s = NormalizeSlug(s)
+	} else {
+		s = strings.TrimSpace(s)
+	}
+	return s, ValidateSlug(s)
+}
+
+// checkSlugs validates every network directory and chain file name under
+// networks/ in fsys.
+func checkSlugs(fsys fs.FS) error {
+	nets, err := fs.ReadDir(fsys, "networks")
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, n := range nets {
+		if !n.IsDir() {
+			continue
+		}
+		if err := ValidateSlug(n.Name()); err != nil {
+			errs = append(errs, fmt.Errorf("networks/%s: %w", n.Name(), err))
+			continue
+		}
+		files, err := fs.ReadDir(fsys, path.Join("networks", n.Name()))
+		if err != nil {
+			return err
+		}
+		for _, f := range files {
+			name := f.Name()
+			if f.IsDir() || strings.EqualFold(name, "compose.toml") || !strings.HasSuffix(name, ".toml") {
+				continue
+			}
+			if err := ValidateSlug(strings.TrimSuffix(name, ".toml")); err != nil {
+				errs = append(errs, fmt.Errorf("networks/%s/%s: %w", n.Name(), name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
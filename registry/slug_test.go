@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidateSlug(t *testing.T) {
+	valid := []string{"hoodi", "hoodi-dev", "rollup-a", "l2", "0x1"}
+	invalid := []string{"", "Hoodi", "-dev", "rollup_a", "rollup a", "../hoodi", "..", "a/b", `a\b`, "héllo"}
+	for _, s := range valid {
+		if err := ValidateSlug(s); err != nil {
+			t.Errorf("ValidateSlug(%q) = %v, want nil", s, err)
+		}
+	}
+	for _, s := range invalid {
+		if err := ValidateSlug(s); !errors.Is(err, ErrInvalidSlug) {
+			t.Errorf("ValidateSlug(%q) = %v, want ErrInvalidSlug", s, err)
+		}
+	}
+	if got := NormalizeSlug("  Hoodi-Dev\n"); got != "hoodi-dev" {
+		t.Errorf("NormalizeSlug = %q, want hoodi-dev", got)
+	}
+}
+
+func TestLookups_InvalidSlug(t *testing.T) {
+	r := New()
+	if _, err := r.GetNetworkBySlug("../data"); !errors.Is(err, ErrInvalidSlug) {
+		t.Fatalf("GetNetworkBySlug(../data) error = %v, want ErrInvalidSlug", err)
+	}
+	for _, id := range []string{"hoodi/../hoodi-dev/rollup-a", "hoodi/rollup-a/x", "Hoodi/Rollup-A"} {
+		if _, err := r.GetChainByIdentifier(id); !errors.Is(err, ErrInvalidSlug) {
+			t.Fatalf("GetChainByIdentifier(%q) error = %v, want ErrInvalidSlug", id, err)
+		}
+	}
+	if _, err := r.Resolve("Rollup A"); !errors.Is(err, ErrInvalidSlug) {
+		t.Fatalf("Resolve(Rollup A) error = %v, want ErrInvalidSlug", err)
+	}
+
+	c, err := New(WithNormalizedSlugs()).GetChainByIdentifier(" Hoodi/Rollup-A ")
+	if err != nil {
+		t.Fatalf("normalized GetChainByIdentifier error: %v", err)
+	}
+	if c.Identifier() != "hoodi/rollup-a" || c.Alias() != "" {
+		t.Fatalf("normalized lookup = %s (alias %q), want hoodi/rollup-a", c.Identifier(), c.Alias())
+	}
+}
+
+func TestNewFromFS_InvalidNames(t *testing.T) {
+	_, err := NewFromFS(fstest.MapFS{
+		"networks/Hoodi/compose.toml": {Data: []byte("")},
+		"networks/ok/compose.toml":    {Data: []byte("")},
+		"networks/ok/rollup_a.toml":   {Data: []byte("")},
+		"networks/ok/rollup-b.toml":   {Data: []byte("")},
+		"networks/ok/README.md":       {Data: []byte("")},
+		"networks/ok/genesis/x.json":  {Data: []byte("")},
+	})
+	if !errors.Is(err, ErrInvalidSlug) {
+		t.Fatalf("NewFromFS error = %v, want ErrInvalidSlug", err)
+	}
+	if err := checkSlugs(New().FS()); err != nil {
+		t.Fatalf("embedded data has invalid names: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+var defaultRegistry atomic.Pointer[Registry]
+
+// Default returns the registry that UnmarshalText and Set resolve against:
+// the one passed to SetDefault, or New().
+func Default() Registry {
+	if r := defaultRegistry.Load(); r != nil {
+		return *r
+	}
+	return New()
+}
+
+// SetDefault makes r the registry that Chain and Network values decoded
+// from flags, config files or environment variables resolve against. Call
+// it before parsing.
+func SetDefault(r Registry) { defaultRegistry.Store(&r) }
+
+// String returns the chain identifier, or "" for a zero Chain.
+func (c Chain) String() string {
+	if c.slug == "" {
+		return ""
+	}
+	return c.Identifier()
+}
+
+// MarshalText implements encoding.TextMarshaler; see String.
+func (c Chain) MarshalText() ([]byte, error) { return []byte(c.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts any chain
+// selector understood by Resolve, looked up in Default(). Empty text
+// yields a zero Chain.
+func (c *Chain) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*c = Chain{}
+		return nil
+	}
+	r := Default()
+	res, err := r.Resolve(s)
+	if err == nil && !res.IsChain() {
+		err = fmt.Errorf("%w: %q names a network, want <network>/<chain>", ErrChainNotFound, s)
+	}
+	if err != nil {
+		return withValidChains(r, err)
+	}
+	*c = res.Chain
+	return nil
+}
+
+// Set implements flag.Value.
+func (c *Chain) Set(s string) error { return c.UnmarshalText([]byte(s)) }
+
+// String returns the network slug.
+func (n Network) String() string { return n.slug }
+
+// MarshalText implements encoding.TextMarshaler; see String.
+func (n Network) MarshalText() ([]byte, error) { return []byte(n.slug), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts a network
+// slug or alias, or any other network selector understood by Resolve,
+// looked up in Default(). Empty text yields a zero Network.
+func (n *Network) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if s == "" {
+		*n = Network{}
+		return nil
+	}
+	r := Default()
+	res, err := r.Resolve(s)
+	if err == nil && res.IsChain() {
+		err = fmt.Errorf("%w: %q names a chain, want a network", ErrNetworkNotFound, s)
+	} else if errors.Is(err, ErrChainNotFound) {
+		err = r.networkNotFound(s, "")
+	}
+	if err != nil {
+		return withValidNetworks(r, err)
+	}
+	*n = res.Network
+	return nil
+}
+
+// Set implements flag.Value.
+func (n *Network) Set(s string) error { return n.UnmarshalText([]byte(s)) }
+
+func withValidChains(r Registry, err error) error {
+	chains, lerr := r.ListChains(ExcludeRetired())
+	if lerr != nil || len(chains) == 0 {
+		return err
+	}
+	ids := make([]string, 0, len(chains))
+	for _, c := range chains {
+		ids = append(ids, c.Identifier())
+	}
+	return fmt.Errorf("%w (valid: %s)", err, strings.Join(ids, ", "))
+}
+
+func withValidNetworks(r Registry, err error) error {
+	nets, lerr := r.ListNetworks(ExcludeRetired())
+	if lerr != nil || len(nets) == 0 {
+		return err
+	}
+	slugs := make([]string, 0, len(nets))
+	for _, n := range nets {
+		slugs = append(slugs, n.slug)
+	}
+	return fmt.Errorf("%w (valid: %s)", err, strings.Join(slugs, ", "))
+}
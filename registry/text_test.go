@@ -0,0 +1,78 @@
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func useDefault(t *testing.T) {
+	t.Helper()
+	r, err := NewFromDir("../data")
+	if err != nil {
+		t.Fatalf("NewFromDir error: %v", err)
+	}
+	prev := defaultRegistry.Load()
+	SetDefault(r)
+	t.Cleanup(func() { defaultRegistry.Store(prev) })
+}
+
+func TestChain_Flag(t *testing.T) {
+	useDefault(t)
+	var c Chain
+	var n Network
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&c, "chain", "chain")
+	fs.Var(&n, "network", "network")
+	if err := fs.Parse([]string{"-chain", "eip155:22224", "-network", "hoodi-dev"}); err != nil {
+		t.Fatalf("Parse error: %v", err)
+	}
+	if c.Identifier() != "hoodi/rollup-b" || n.Slug() != "hoodi-dev" {
+		t.Fatalf("flags = %s, %s", c, n)
+	}
+	if cfg, err := c.LoadConfig(); err != nil || cfg.ChainID != 22224 {
+		t.Fatalf("LoadConfig = %d, %v", cfg.ChainID, err)
+	}
+
+	err := c.Set("hoodi/rollup-z")
+	if !errors.Is(err, ErrChainNotFound) || !strings.Contains(err.Error(), "valid: hoodi/rollup-a, hoodi/rollup-b") {
+		t.Fatalf("Set(hoodi/rollup-z) error = %v, want ErrChainNotFound listing valid chains", err)
+	}
+	if err := c.Set("hoodi"); !errors.Is(err, ErrChainNotFound) {
+		t.Fatalf("Set(hoodi) error = %v, want ErrChainNotFound", err)
+	}
+	err = n.Set("mainnet")
+	if !errors.Is(err, ErrNetworkNotFound) || !strings.Contains(err.Error(), "valid: hoodi, hoodi-dev, sepolia-dev") {
+		t.Fatalf("Network.Set(mainnet) error = %v, want ErrNetworkNotFound listing valid networks", err)
+	}
+}
+
+func TestChain_TextRoundTrip(t *testing.T) {
+	useDefault(t)
+	var cfg struct {
+		Chain   Chain   `json:"chain" toml:"chain"`
+		Network Network `json:"network" toml:"network"`
+		Unset   Chain   `json:"unset" toml:"unset"`
+	}
+	if _, err := toml.Decode("chain = \"sepolia-dev/rollup-a\"\nnetwork = \"hoodi\"\n", &cfg); err != nil {
+		t.Fatalf("toml.Decode error: %v", err)
+	}
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+	if want := `{"chain":"sepolia-dev/rollup-a","network":"hoodi","unset":""}`; string(b) != want {
+		t.Fatalf("json = %s, want %s", b, want)
+	}
+	cfg.Chain, cfg.Network = Chain{}, Network{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if cfg.Chain.Identifier() != "sepolia-dev/rollup-a" || cfg.Network.Slug() != "hoodi" || cfg.Unset.String() != "" {
+		t.Fatalf("decoded = %+v", cfg)
+	}
+}
@@ -0,0 +1,200 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Validate loads every visible network and chain and checks the rules that
+// make validate and WithEagerValidation enforce:
+//
+//   - every network has a known environment
+//   - aliases are valid slugs and unique: a network alias is neither a
+//     network slug nor another network's alias, and a chain alias is neither
+//     a chain slug nor another chain's alias in the same network
+//   - every chain has a chain_id and a valid [native_currency]
+//   - [lifecycle] tables are valid and a replacement names another existing
+//     network or chain
+//   - addresses are 0x-prefixed 20-byte values; zero and placeholder
+//     addresses are allowed in devnets, or elsewhere in fields the config
+//     lists under placeholders; otherwise an undeployed contract is left
+//     empty
+//   - every field listed under placeholders holds a zero or placeholder
+//     address
+//
+// All failures are returned, joined.
+func (r Registry) Validate() error {
+	if s := r.settings; s != nil && s.opts.deprecation != nil {
+		// Lookups made while validating are not deprecation uses.
+		quiet := *s
+		quiet.opts.deprecation = nil
+		r.settings = &quiet
+	}
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	owner := map[string]string{}
+	for _, n := range nets {
+		owner[n.slug] = n.slug
+	}
+	for _, n := range nets {
+		errs = append(errs, r.validateNetwork(n, owner)...)
+	}
+	return errors.Join(errs...)
+}
+
+// validateNetwork checks n and its chains; netOwner holds the network slugs
+// and the network aliases claimed so far.
+func (r Registry) validateNetwork(n Network, netOwner map[string]string) []error {
+	ncfg, err := n.LoadConfig()
+	if err != nil {
+		return []error{err}
+	}
+	errs := claimAliases(netOwner, ncfg.Aliases, n.slug, n.slug)
+	env := ncfg.Environment
+	if !env.Valid() {
+		errs = append(errs, fmt.Errorf("%s: environment must be mainnet, testnet or devnet, got %q", n.slug, env))
+	}
+	if err := validateLifecycle(ncfg.Lifecycle, n.slug, func(s string) error {
+		_, err := r.GetNetworkBySlug(s)
+		return err
+	}); err != nil {
+		errs = append(errs, err)
+	}
+	known := placeholderSet(ncfg.Placeholders)
+	errs = append(errs, checkAddress(env, n.slug, "publisher.superblock_contract", ncfg.Publisher.SuperblockContract, known))
+	errs = append(errs, checkAddress(env, n.slug, "publisher.dispute_game_factory", ncfg.Publisher.DisputeGameFactory, known))
+	errs = append(errs, stalePlaceholders(n.slug, known)...)
+
+	chains, err := n.ListChains()
+	if err != nil {
+		return append(errs, err)
+	}
+	chainOwner := make(map[string]string, len(chains))
+	for _, c := range chains {
+		chainOwner[c.slug] = c.slug
+	}
+	for _, c := range chains {
+		id := c.Identifier()
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, claimAliases(chainOwner, cfg.Aliases, c.slug, id)...)
+		if cfg.ChainID == 0 {
+			errs = append(errs, fmt.Errorf("%s: chain_id required", id))
+		}
+		if err := cfg.NativeCurrency.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", id, err))
+		}
+		if err := validateLifecycle(cfg.Lifecycle, id, func(s string) error {
+			_, err := r.GetChainByIdentifier(s)
+			return err
+		}); err != nil {
+			errs = append(errs, err)
+		}
+		var v struct {
+			Addresses map[string]Address `toml:"addresses"`
+		}
+		if err := c.Decode(&v); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		names := make([]string, 0, len(v.Addresses))
+		for name := range v.Addresses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		known := placeholderSet(cfg.Placeholders)
+		for _, name := range names {
+			errs = append(errs, checkAddress(env, id, "addresses."+name, v.Addresses[name], known))
+		}
+		errs = append(errs, stalePlaceholders(id, known)...)
+	}
+	return errs
+}
+
+// claimAliases records aliases as owned by slug in owner and returns an
+// error for each alias that is not a valid slug or is already taken by a
+// slug or another alias; where names the entry in errors.
+func claimAliases(owner map[string]string, aliases []string, slug, where string) []error {
+	var errs []error
+	for _, a := range aliases {
+		if err := ValidateSlug(a); err != nil {
+			errs = append(errs, fmt.Errorf("%s: alias: %w", where, err))
+			continue
+		}
+		switch prev, ok := owner[a]; {
+		case !ok:
+			owner[a] = slug
+		case prev == a:
+			errs = append(errs, fmt.Errorf("%s: alias %q collides with an existing slug", where, a))
+		default:
+			errs = append(errs, fmt.Errorf("%s: alias %q is already an alias of %s", where, a, prev))
+		}
+	}
+	return errs
+}
+
+// checkAddress returns an error for a malformed address, or for a zero or
+// placeholder address outside a devnet unless field is in known. Fields it
+// accepts through known are removed from it.
+func checkAddress(env Environment, where, field string, a Address, known map[string]bool) error {
+	k := a.Kind()
+	if known[field] && (k == AddressZero || k == AddressPlaceholder) {
+		delete(known, field)
+		return nil
+	}
+	switch {
+	case k == AddressInvalid:
+		return fmt.Errorf("%s: %s: %q is not a 0x-prefixed 20-byte address", where, field, a)
+	case env != EnvDevnet && (k == AddressZero || k == AddressPlaceholder):
+		return fmt.Errorf("%s: %s: %s address %s in %s network; leave it empty until deployed or list it in placeholders", where, field, k, a, env)
+	}
+	return nil
+}
+
+func placeholderSet(fields []string) map[string]bool {
+	known := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		known[f] = true
+	}
+	return known
+}
+
+// stalePlaceholders reports the fields left in known by checkAddress: ones
+// that do not exist or no longer hold a zero or placeholder address.
+func stalePlaceholders(where string, known map[string]bool) []error {
+	fields := make([]string, 0, len(known))
+	for f := range known {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	errs := make([]error, 0, len(fields))
+	for _, f := range fields {
+		errs = append(errs, fmt.Errorf("%s: placeholders lists %s, which does not hold a zero or placeholder address", where, f))
+	}
+	return errs
+}
+
+// validateLifecycle checks l for the entry named where; resolve looks up
+// the replacement.
+func validateLifecycle(l Lifecycle, where string, resolve func(string) error) error {
+	if err := l.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", where, err)
+	}
+	if l.Replacement == "" {
+		return nil
+	}
+	if l.Replacement == where {
+		return fmt.Errorf("%s: lifecycle replacement points to itself", where)
+	}
+	if err := resolve(l.Replacement); err != nil {
+		return fmt.Errorf("%s: lifecycle replacement: %w", where, err)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package registry
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const (
+	validNetwork = "environment = \"testnet\"\n[l1]\nchain_id = 1\n"
+	etherTable   = "[native_currency]\nname = \"Ether\"\nsymbol = \"ETH\"\ndecimals = 18\n"
+)
+
+func TestRegistry_Validate(t *testing.T) {
+	cases := []struct {
+		name    string
+		network string
+		chain   string
+		want    string // substring of the error; "" for valid
+	}{
+		{"valid", validNetwork, "chain_id = 2\n[addresses]\nMailbox = \"0x248721a59a2756E579026aDA017bd9B6adFe3e57\"\n" + etherTable, ""},
+		{"no environment", "[l1]\nchain_id = 1\n", "chain_id = 2\n", "environment must be"},
+		{"unknown environment", "environment = \"staging\"\n", "chain_id = 2\n", "environment must be"},
+		{"no chain id", validNetwork, "name = \"rollup\"\n", "chain_id required"},
+		{"no currency", validNetwork, "chain_id = 2\n", "native_currency.name required"},
+		{"bad currency", validNetwork, "chain_id = 2\n[native_currency]\nname = \"Ether\"\nsymbol = \"E\"\ndecimals = 18\n", "native_currency.symbol"},
+		{"malformed address", validNetwork, "chain_id = 2\n[addresses]\nBridge = \"0x1234\"\n", "addresses.Bridge"},
+		{"placeholder outside devnet", validNetwork, "chain_id = 2\n[addresses]\nBridge = \"0x0000000000000000000000000000000000000001\"\n", "leave it empty"},
+		{"zero publisher outside devnet", validNetwork + "[publisher]\nsuperblock_contract = \"0x0000000000000000000000000000000000000000\"\n", "chain_id = 2\n", "publisher.superblock_contract"},
+		{"listed placeholder outside devnet", validNetwork, "placeholders = [\"addresses.Bridge\"]\nchain_id = 2\n[addresses]\nBridge = \"0x0000000000000000000000000000000000000001\"\n" + etherTable, ""},
+		{"listed publisher placeholder", "placeholders = [\"publisher.superblock_contract\"]\n" + validNetwork + "[publisher]\nsuperblock_contract = \"0x0000000000000000000000000000000000000001\"\n", "chain_id = 2\n" + etherTable, ""},
+		{"stale placeholder", validNetwork, "placeholders = [\"addresses.Mailbox\"]\nchain_id = 2\n[addresses]\nMailbox = \"0x248721a59a2756E579026aDA017bd9B6adFe3e57\"\n" + etherTable, "placeholders lists addresses.Mailbox"},
+		{"unknown placeholder field", "placeholders = [\"publisher.bridge\"]\n" + validNetwork, "chain_id = 2\n" + etherTable, "placeholders lists publisher.bridge"},
+		{"placeholder in devnet", "environment = \"devnet\"\n", "chain_id = 2\n[addresses]\nMailbox = \"0x0000000000000000000000000000000000000001\"\n" + etherTable, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewFromFS(fstest.MapFS{
+				"networks/net/compose.toml": {Data: []byte(tc.network)},
+				"networks/net/rollup.toml":  {Data: []byte(tc.chain)},
+			})
+			if err != nil {
+				t.Fatalf("NewFromFS error: %v", err)
+			}
+			err = r.Validate()
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("Validate() error: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("Validate() = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Validate_Lifecycle(t *testing.T) {
+	const deprecated = "[lifecycle]\nstatus = \"deprecated\"\ndeprecated_on = 2026-01-01\n"
+	cases := []struct {
+		name   string
+		old    string // networks/net/old.toml after chain_id
+		oldNet string // networks/old-net/compose.toml after environment
+		want   string
+	}{
+		{"chain replacement", deprecated + "replacement = \"net/new\"\n", "", ""},
+		{"chain replacement by alias", deprecated + "replacement = \"net/newest\"\n", "", ""},
+		{"network replacement", "", deprecated + "replacement = \"net\"\n", ""},
+		{"missing chain replacement", deprecated + "replacement = \"net/gone\"\n", "", "lifecycle replacement: chain not found"},
+		{"missing network replacement", "", deprecated + "replacement = \"gone\"\n", "lifecycle replacement: network not found"},
+		{"chain replaces itself", deprecated + "replacement = \"net/old\"\n", "", "points to itself"},
+		{"network replaces itself", "", deprecated + "replacement = \"old-net\"\n", "points to itself"},
+		{"missing deprecated_on", "[lifecycle]\nstatus = \"retired\"\n", "", "require deprecated_on"},
+		{"active with deprecated_on", "[lifecycle]\ndeprecated_on = 2026-01-01\n", "", "must not set deprecated_on"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewFromFS(fstest.MapFS{
+				"networks/net/compose.toml":     {Data: []byte(validNetwork)},
+				"networks/net/old.toml":         {Data: []byte("chain_id = 2\n" + tc.old + etherTable)},
+				"networks/net/new.toml":         {Data: []byte("chain_id = 3\naliases = [\"newest\"]\n" + etherTable)},
+				"networks/old-net/compose.toml": {Data: []byte("environment = \"testnet\"\n" + tc.oldNet)},
+			})
+			if err != nil {
+				t.Fatalf("NewFromFS error: %v", err)
+			}
+			err = r.Validate()
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("Validate() error: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("Validate() = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Validate_Aliases(t *testing.T) {
+	const chain = "chain_id = 2\n" + etherTable
+	cases := []struct {
+		name  string
+		files map[string]string // path → aliases line
+		want  string
+	}{
+		{"distinct", map[string]string{
+			"networks/hoodi/compose.toml":  `aliases = ["hoodi-old"]`,
+			"networks/hoodi/rollup-a.toml": `aliases = ["rollup-old"]`,
+			"networks/dev/compose.toml":    `aliases = ["dev-old"]`,
+			"networks/dev/rollup-a.toml":   `aliases = ["rollup-old"]`, // chain aliases are per network
+		}, ""},
+		{"network alias is a network slug", map[string]string{
+			"networks/hoodi/compose.toml": `aliases = ["dev"]`,
+			"networks/dev/compose.toml":   ``,
+		}, `hoodi: alias "dev" collides with an existing slug`},
+		{"network alias used twice", map[string]string{
+			"networks/dev/compose.toml":   `aliases = ["old"]`,
+			"networks/hoodi/compose.toml": `aliases = ["old"]`,
+		}, `hoodi: alias "old" is already an alias of dev`},
+		{"chain alias is a chain slug", map[string]string{
+			"networks/hoodi/compose.toml":  ``,
+			"networks/hoodi/rollup-a.toml": `aliases = ["rollup-b"]`,
+			"networks/hoodi/rollup-b.toml": ``,
+		}, `hoodi/rollup-a: alias "rollup-b" collides with an existing slug`},
+		{"chain alias used twice", map[string]string{
+			"networks/hoodi/compose.toml":  ``,
+			"networks/hoodi/rollup-a.toml": `aliases = ["old"]`,
+			"networks/hoodi/rollup-b.toml": `aliases = ["old"]`,
+		}, `hoodi/rollup-b: alias "old" is already an alias of rollup-a`},
+		{"alias repeated in one file", map[string]string{
+			"networks/hoodi/compose.toml":  ``,
+			"networks/hoodi/rollup-a.toml": `aliases = ["old", "old"]`,
+		}, `hoodi/rollup-a: alias "old" is already an alias of rollup-a`},
+		{"path-like alias", map[string]string{
+			"networks/hoodi/compose.toml": `aliases = ["../hoodi"]`,
+		}, `hoodi: alias: invalid slug`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, aliases := range tc.files {
+				body := chain
+				if strings.HasSuffix(name, "/compose.toml") {
+					body = validNetwork
+				}
+				fsys[name] = &fstest.MapFile{Data: []byte(aliases + "\n" + body)}
+			}
+			r, err := NewFromFS(fsys)
+			if err != nil {
+				t.Fatalf("NewFromFS error: %v", err)
+			}
+			err = r.Validate()
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("Validate() error: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("Validate() = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestClaimAliases(t *testing.T) {
+	cases := []struct {
+		name    string
+		aliases []string
+		want    string // substring of the error; "" for ok
+	}{
+		{"new alias", []string{"rollup-old"}, ""},
+		{"alias of a slug", []string{"rollup-b"}, "collides with an existing slug"},
+		{"alias of another alias", []string{"rollup-x"}, "already an alias of rollup-b"},
+		{"repeated alias", []string{"rollup-new", "rollup-new"}, "already an alias of rollup-a"},
+		{"path-like alias", []string{"../rollup"}, "invalid slug"},
+		{"uppercase alias", []string{"Rollup-Old"}, "invalid slug"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			owner := map[string]string{"rollup-a": "rollup-a", "rollup-b": "rollup-b", "rollup-x": "rollup-b"}
+			err := errors.Join(claimAliases(owner, tc.aliases, "rollup-a", "hoodi/rollup-a")...)
+			switch {
+			case tc.want == "" && err != nil:
+				t.Fatalf("claimAliases error: %v", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Fatalf("claimAliases = %v, want error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Validate_Data(t *testing.T) {
+	if err := dataRegistry(t).Validate(); err != nil {
+		t.Fatalf("../data fails validation: %v", err)
+	}
+}
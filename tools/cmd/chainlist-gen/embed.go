@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+// embedVariant is one generated registry_nodev embed file of package assets.
+type embedVariant struct {
+	file     string
+	tags     string
+	doc      string
+	genesis  bool
+	paths    []string // data-relative, filled by writeEmbeds
+	devSlugs []string
+}
+
+// writeEmbeds regenerates the registry_nodev embed files in dir (the
+// repository root), which list the non-devnet networks of dataDir and the
+// genesis blobs their chains reference. go:embed cannot exclude paths, so
+// the lists are generated rather than written by hand.
+func writeEmbeds(dataDir, dir string) error {
+	r, err := reg.NewFromDir(dataDir)
+	if err != nil {
+		return err
+	}
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return err
+	}
+	var keep, devnets, genesis []string
+	seen := map[string]bool{}
+	for _, n := range nets {
+		env, err := n.Environment()
+		if err != nil {
+			return err
+		}
+		if env == reg.EnvDevnet {
+			devnets = append(devnets, n.Slug())
+			continue
+		}
+		keep = append(keep, path.Join("networks", n.Slug()))
+		chains, err := n.ListChains()
+		if err != nil {
+			return err
+		}
+		for _, c := range chains {
+			p, err := c.GenesisPath()
+			if err != nil {
+				return err
+			}
+			if seen[p] {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(dataDir, filepath.FromSlash(p))); err != nil {
+				continue // no genesis for this chain
+			}
+			seen[p] = true
+			genesis = append(genesis, p)
+		}
+	}
+	sort.Strings(genesis)
+	variants := []embedVariant{
+		{
+			file:    "embedded_nodev.go",
+			tags:    "registry_nodev && !registry_nogenesis",
+			doc:     "FS contains the embedded network configs of non-devnet networks and the\n// genesis files their chains reference.",
+			genesis: true,
+		},
+		{
+			file: "embedded_nodev_nogenesis.go",
+			tags: "registry_nodev && registry_nogenesis",
+			doc:  "FS contains the embedded network configs of non-devnet networks.",
+		},
+	}
+	for _, v := range variants {
+		v.paths = keep
+		if v.genesis {
+			v.paths = append(append([]string(nil), keep...), genesis...)
+		}
+		v.devSlugs = devnets
+		src, err := v.render()
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.file, err)
+		}
+		dest := filepath.Join(dir, v.file)
+		if err := os.WriteFile(dest, src, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s (paths=%d)\n", dest, len(v.paths))
+	}
+	return nil
+}
+
+func (v embedVariant) render() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by chainlist-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "//go:build %s\n\npackage assets\n\nimport \"embed\"\n\n", v.tags)
+	fmt.Fprintf(&b, "// %s\n//\n", v.doc)
+	for _, p := range v.paths {
+		fmt.Fprintf(&b, "//go:embed data/%s\n", p)
+	}
+	fmt.Fprintf(&b, "var FS embed.FS\n\n")
+	fmt.Fprintf(&b, "// What this build embeds; see the registry_nogenesis and registry_nodev build tags.\n")
+	fmt.Fprintf(&b, "const (\n\tGenesisEmbedded = %t\n\tDevNetworksEmbedded = false\n)\n\n", v.genesis)
+	fmt.Fprintf(&b, "// OmittedNetworks lists the devnet networks left out of this build.\n")
+	fmt.Fprintf(&b, "var OmittedNetworks = []string{")
+	for i, s := range v.devSlugs {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(strconv.Quote(s))
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -11,23 +11,20 @@ import (
 
 	"github.com/BurntSushi/toml"
 	t "github.com/compose-network/registry/internal/types"
+	reg "github.com/compose-network/registry/registry"
 )
 
-type chainCfg struct {
-	Name                 string `toml:"name"`
-	ChainID              uint64 `toml:"chain_id"`
-	PublicRPC            string `toml:"public_rpc"`
-	Explorer             string `toml:"explorer"`
-	DataAvailabilityType string `toml:"data_availability_type"`
-}
-
 func main() {
 	var base string
 	var outToml string
 	var outJSON string
+	var outEIP3085 string
+	var outEmbed string
 	flag.StringVar(&base, "base", ".", "repository root (registry module)")
 	flag.StringVar(&outToml, "out-toml", "data/chainList.toml", "output TOML path")
 	flag.StringVar(&outJSON, "out-json", "data/chainList.json", "output JSON path")
+	flag.StringVar(&outEIP3085, "out-eip3085", "data/eip3085.json", "output EIP-3085 wallet_addEthereumChain JSON path")
+	flag.StringVar(&outEmbed, "out-embed", ".", "directory of the generated registry_nodev embed files of package assets (empty to skip)")
 	flag.Parse()
 
 	cfgRoot := filepath.Join(base, "data", "networks")
@@ -37,6 +34,8 @@ func main() {
 	}
 
 	var out t.ChainListTOML
+	// EIP-3085 parameters keyed by identifier
+	addChain := make(map[string]reg.AddEthereumChainParameter)
 	expected := 0
 	for _, n := range networks {
 		if !n.IsDir() {
@@ -57,11 +56,14 @@ func main() {
 				continue
 			}
 			path := filepath.Join(dir, name)
-			var cfg chainCfg
+			var cfg reg.ChainConfig
 			if _, err := toml.DecodeFile(path, &cfg); err != nil {
 				fatalf("decode %s: %v", path, err)
 			}
 			slug := strings.TrimSuffix(name, ".toml")
+			if err := cfg.NativeCurrency.Validate(); err != nil {
+				fatalf("%s: %v", path, err)
+			}
 			entry := t.ChainListEntry{
 				Name:                 cfg.Name,
 				Identifier:           network + "/" + slug,
@@ -70,8 +72,13 @@ func main() {
 				Explorers:            []string{},
 				DataAvailabilityType: defaultDA(cfg.DataAvailabilityType),
 				Parent:               t.ChainListEntryParent{Type: "L2", Chain: network},
-				GasPayingToken:       "",
-				FaultProofs:          nil,
+				NativeCurrency: &t.NativeCurrency{
+					Name:     cfg.NativeCurrency.Name,
+					Symbol:   cfg.NativeCurrency.Symbol,
+					Decimals: cfg.NativeCurrency.Decimals,
+				},
+				GasPayingToken: string(cfg.NativeCurrency.L1Token),
+				FaultProofs:    nil,
 			}
 			if strings.TrimSpace(cfg.PublicRPC) != "" {
 				entry.RPC = []string{cfg.PublicRPC}
@@ -80,6 +87,7 @@ func main() {
 				entry.Explorers = []string{cfg.Explorer}
 			}
 			out.Chains = append(out.Chains, entry)
+			addChain[entry.Identifier] = cfg.EIP3085()
 			expected++
 		}
 	}
@@ -122,6 +130,29 @@ func main() {
 		fatalf("encode %s: %v", jdest, err)
 	}
 	fmt.Printf("wrote %s (chains=%d)\n", jdest, len(out.Chains))
+
+	// Write EIP-3085 JSON
+	edest := filepath.Join(base, outEIP3085)
+	if err := os.MkdirAll(filepath.Dir(edest), 0o755); err != nil {
+		fatalf("mkdir data: %v", err)
+	}
+	ef, err := os.Create(edest)
+	if err != nil {
+		fatalf("create %s: %v", edest, err)
+	}
+	defer func() { _ = ef.Close() }()
+	eenc := json.NewEncoder(ef)
+	eenc.SetIndent("", "  ")
+	if err := eenc.Encode(addChain); err != nil {
+		fatalf("encode %s: %v", edest, err)
+	}
+	fmt.Printf("wrote %s (chains=%d)\n", edest, len(addChain))
+
+	if outEmbed != "" {
+		if err := writeEmbeds(filepath.Join(base, "data"), filepath.Join(base, outEmbed)); err != nil {
+			fatalf("write embeds: %v", err)
+		}
+	}
 }
 
 func defaultDA(in string) string {
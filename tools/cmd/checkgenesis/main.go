@@ -1,38 +1,65 @@
 package main
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"io"
+	"io/fs"
+	"math/big"
 	"os"
 	"path/filepath"
-	"strconv"
+	"sort"
 	"strings"
 
 	"github.com/BurntSushi/toml"
 	t "github.com/compose-network/registry/internal/types"
 	reg "github.com/compose-network/registry/registry"
-	"github.com/klauspost/compress/zstd"
 )
 
 // minimal view of Ethereum genesis we care about
 type genesisCfg struct {
-	Config struct {
-		ChainID any `json:"chainId"`
-	} `json:"config"`
-	Timestamp any `json:"timestamp"`
+	Config        map[string]any  `json:"config"`
+	Timestamp     any             `json:"timestamp"`
+	GasLimit      any             `json:"gasLimit"`
+	BaseFeePerGas any             `json:"baseFeePerGas"`
+	ExtraData     *string         `json:"extraData"`
+	Alloc         json.RawMessage `json:"alloc"`
 }
 
+// Hardfork activation fields, each list in activation order. Block-based
+// forks are compared by number, time-based forks by timestamp.
+var (
+	blockForks = []string{
+		"homesteadBlock", "eip150Block", "eip155Block", "eip158Block",
+		"byzantiumBlock", "constantinopleBlock", "petersburgBlock", "istanbulBlock",
+		"muirGlacierBlock", "berlinBlock", "londonBlock", "arrowGlacierBlock",
+		"grayGlacierBlock", "mergeNetsplitBlock",
+	}
+	l1TimeForks = []string{"shanghaiTime", "cancunTime", "pragueTime", "osakaTime"}
+	opTimeForks = []string{
+		"regolithTime", "canyonTime", "ecotoneTime", "fjordTime",
+		"graniteTime", "holoceneTime", "isthmusTime", "jovianTime",
+	}
+	// OP Stack forks that must activate together with an L1 fork.
+	pairedForks = [][2]string{
+		{"canyonTime", "shanghaiTime"},
+		{"ecotoneTime", "cancunTime"},
+		{"isthmusTime", "pragueTime"},
+	}
+)
+
 func main() {
 	var base string
 	flag.StringVar(&base, "base", ".", "repository root")
 	flag.Parse()
 
-	// Load compose chains from embedded TOMLs (generic networks API)
-	r := reg.New()
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
 	networks, err := r.ListNetworks()
 	if err != nil {
 		fatalf("list networks: %v", err)
@@ -46,139 +73,279 @@ func main() {
 	if _, err := toml.DecodeFile(filepath.Join(base, "data/chainList.toml"), &cl); err != nil {
 		fatalf("decode chainList.toml: %v", err)
 	}
-	idsByIdentifier := make(map[string]int64)
+	idsByIdentifier := make(map[string]uint64)
 	for _, c := range cl.Chains {
-		idsByIdentifier[c.Identifier] = int64(c.ChainID)
+		idsByIdentifier[c.Identifier] = c.ChainID
 	}
 
-	// For each network, check all chains
+	// Violations keyed by genesis path, reported together at the end.
+	violations := make(map[string][]string)
+	// Genesis files referenced by some chain, relative to data/.
+	referenced := make(map[string]bool)
 	for _, n := range networks {
-		networkSlug := n.Slug()
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			fatalf("load network %s: %v", n.Slug(), err)
+		}
 		chains, err := n.ListChains()
 		if err != nil {
-			fatalf("list chains for %s: %v", networkSlug, err)
+			fatalf("list chains for %s: %v", n.Slug(), err)
 		}
-
-		// For each chain, ensure genesis exists, decode and compare ids & time
 		for _, c := range chains {
-			slug := c.Slug()
-			identifier := c.Identifier()
 			ccfg, err := c.LoadConfig()
 			if err != nil {
-				fatalf("load chain %s: %v", identifier, err)
+				fatalf("load chain %s: %v", c.Identifier(), err)
 			}
-			expectID := int64(ccfg.ChainID)
-			if id2, ok := idsByIdentifier[identifier]; ok && id2 != expectID {
-				fatalf("identifier %s: chainList chain_id=%d != compose chain_id=%d", identifier, id2, expectID)
+			rel, err := c.GenesisPath()
+			if err != nil {
+				key := filepath.Join(base, "data/networks", n.Slug(), c.Slug()+".toml")
+				violations[key] = append(violations[key], err.Error())
+				continue
 			}
-
-			genPath := filepath.Join(base, "data/genesis", networkSlug, slug+".json.zst")
-			f, err := os.Open(genPath)
+			referenced[rel] = true
+			genPath := filepath.Join(base, "data", filepath.FromSlash(rel))
+			if id, ok := idsByIdentifier[c.Identifier()]; ok && id != ccfg.ChainID {
+				violations[genPath] = append(violations[genPath],
+					fmt.Sprintf("chainList chain_id=%d != compose chain_id=%d", id, ccfg.ChainID))
+			}
+			raw, err := c.LoadGenesis()
 			if err != nil {
-				fatalf("%s missing: %v", genPath, err)
+				violations[genPath] = append(violations[genPath], err.Error())
+				continue
 			}
-			zr, err := zstd.NewReader(f)
-			var raw []byte
-			if err == nil {
-				raw, err = io.ReadAll(zr)
-				zr.Close()
-				if err != nil {
-					// Fallback to plain JSON if decode fails
-					if _, serr := f.Seek(0, 0); serr != nil {
-						fatalf("seek %s: %v", genPath, serr)
-					}
-					raw, err = io.ReadAll(f)
-					if err != nil {
-						fatalf("read %s: %v", genPath, err)
-					}
-				}
-				if cerr := f.Close(); cerr != nil {
-					fatalf("close %s: %v", genPath, cerr)
-				}
-			} else {
-				// Fallback to plain JSON for dev files
-				if _, serr := f.Seek(0, 0); serr != nil {
-					fatalf("seek %s: %v", genPath, serr)
-				}
-				raw, err = io.ReadAll(f)
-				if cerr := f.Close(); cerr != nil {
-					fatalf("close %s: %v", genPath, cerr)
-				}
-				if err != nil {
-					fatalf("read %s: %v", genPath, err)
-				}
+			violations[genPath] = append(violations[genPath], checkGenesis(raw, ccfg, ncfg)...)
+		}
+	}
+
+	// Garbage check: every file under data/genesis must be referenced.
+	dataDir := filepath.Join(base, "data")
+	err = filepath.WalkDir(filepath.Join(dataDir, "genesis"), func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dataDir, p)
+		if err != nil {
+			return err
+		}
+		if !referenced[filepath.ToSlash(rel)] {
+			violations[p] = append(violations[p], "unreferenced genesis file; no chain points at it")
+		}
+		return nil
+	})
+	if err != nil {
+		fatalf("walk genesis: %v", err)
+	}
+
+	paths := make([]string, 0, len(violations))
+	for p, vs := range violations {
+		if len(vs) > 0 {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) > 0 {
+		sort.Strings(paths)
+		for _, p := range paths {
+			fmt.Fprintf(os.Stderr, "%s:\n", p)
+			for _, v := range violations[p] {
+				fmt.Fprintf(os.Stderr, "  - %s\n", v)
 			}
+		}
+		fatalf("checkgenesis: %d file(s) with violations", len(paths))
+	}
+	fmt.Println("checkgenesis ok")
+}
+
+// checkGenesis returns every violation found in one genesis payload.
+func checkGenesis(raw []byte, ccfg reg.ChainConfig, ncfg reg.NetworkConfig) []string {
+	var out []string
+	report := func(format string, a ...any) { out = append(out, fmt.Sprintf(format, a...)) }
+
+	var g genesisCfg
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&g); err != nil {
+		return []string{fmt.Sprintf("decode genesis: %v", err)}
+	}
+
+	gotID, err := anyToBig(g.Config["chainId"])
+	switch {
+	case err != nil:
+		report("config.chainId: %v", err)
+	case !gotID.IsUint64() || gotID.Uint64() != ccfg.ChainID:
+		report("config.chainId=%s, want %d", gotID, ccfg.ChainID)
+	}
 
-			var g genesisCfg
-			if err := json.Unmarshal(raw, &g); err != nil {
-				fatalf("decode genesis %s: %v", genPath, err)
+	ts, err := anyToBig(g.Timestamp)
+	if err != nil {
+		report("timestamp: %v", err)
+		ts = nil
+	}
+	if ts != nil {
+		// Compare timestamp vs compose TOML genesis.l2_time if present
+		if ccfg.Genesis.L2Time != 0 && (!ts.IsUint64() || ts.Uint64() != ccfg.Genesis.L2Time) {
+			report("timestamp=%s, want l2_time %d", ts, ccfg.Genesis.L2Time)
+		}
+		if l1 := ncfg.L1.GenesisTime; l1 != 0 && ts.Cmp(new(big.Int).SetUint64(l1)) <= 0 {
+			report("timestamp=%s is not after L1 genesis_time %d", ts, l1)
+		}
+	}
+
+	// Placeholder genesis files carry only chainId and timestamp, so they
+	// need no gasLimit; the rules below check whatever else they carry.
+	if g.GasLimit != nil || len(g.Alloc) > 0 {
+		if gl, err := anyToBig(g.GasLimit); err != nil {
+			report("gasLimit: %v", err)
+		} else if gl.Sign() <= 0 {
+			report("gasLimit must be positive, got %s", gl)
+		}
+	}
+
+	london, londonErr := forkValue(g.Config, "londonBlock")
+	if londonErr != nil {
+		report("%v", londonErr)
+	}
+	if london != nil && london.Sign() == 0 {
+		if bf, err := anyToBig(g.BaseFeePerGas); err != nil {
+			report("baseFeePerGas (required with londonBlock=0): %v", err)
+		} else if bf.Sign() <= 0 {
+			report("baseFeePerGas must be positive, got %s", bf)
+		}
+	} else if g.BaseFeePerGas != nil {
+		report("baseFeePerGas set but London is not active at genesis")
+	}
+
+	extra, extraErr := decodeHex(g.ExtraData)
+	if extraErr != nil {
+		report("extraData: %v", extraErr)
+	} else if len(extra) > 32 {
+		report("extraData is %d bytes, max 32", len(extra))
+	}
+
+	forks := make(map[string]*big.Int)
+	for _, name := range append(append(append([]string{}, blockForks...), l1TimeForks...), opTimeForks...) {
+		v, err := forkValue(g.Config, name)
+		if err != nil {
+			report("%v", err)
+			continue
+		}
+		if v != nil {
+			forks[name] = v
+		}
+	}
+	checkOrder := func(names []string, atGenesis *big.Int) {
+		var prev string
+		var prevAt *big.Int
+		for _, name := range names {
+			v, ok := forks[name]
+			if !ok {
+				prev, prevAt = name, nil
+				continue
 			}
-			gotID, err := anyToInt64(g.Config.ChainID)
-			if err != nil {
-				fatalf("%s chainId parse: %v", genPath, err)
+			at := v
+			if atGenesis != nil {
+				// A fork time of 0 means active from genesis. Any other value
+				// must not predate the genesis block.
+				if v.Sign() == 0 {
+					at = atGenesis
+				} else if v.Cmp(atGenesis) < 0 {
+					report("config.%s=%s is before genesis timestamp %s (use 0 for active at genesis)", name, v, atGenesis)
+				}
+			}
+			if prev != "" && prevAt == nil {
+				report("config.%s is set but earlier fork %s is not", name, prev)
+			} else if prevAt != nil && at.Cmp(prevAt) < 0 {
+				report("config.%s=%s activates before earlier fork %s", name, v, prev)
 			}
-			if gotID != expectID {
-				fatalf("%s chainId=%d, want %d", genPath, gotID, expectID)
+			prev, prevAt = name, at
+		}
+	}
+	checkOrder(blockForks, nil)
+	if ts != nil {
+		checkOrder(l1TimeForks, ts)
+		checkOrder(opTimeForks, ts)
+	}
+	for _, p := range pairedForks {
+		a, aok := forks[p[0]]
+		b, bok := forks[p[1]]
+		if aok != bok || (aok && a.Cmp(b) != 0) {
+			report("config.%s and config.%s must activate together", p[0], p[1])
+		}
+	}
+
+	// Holocene moves the EIP-1559 parameters into extraData:
+	// version byte 0, then denominator and elasticity as big-endian uint32.
+	if h, ok := forks["holoceneTime"]; ok && ts != nil && (h.Sign() == 0 || h.Cmp(ts) <= 0) && extraErr == nil {
+		if len(extra) != 9 || extra[0] != 0 {
+			report("extraData must be 9 bytes with version 0 once Holocene is active, got 0x%x", extra)
+		} else {
+			denom := uint64(extra[1])<<24 | uint64(extra[2])<<16 | uint64(extra[3])<<8 | uint64(extra[4])
+			elast := uint64(extra[5])<<24 | uint64(extra[6])<<16 | uint64(extra[7])<<8 | uint64(extra[8])
+			if denom == 0 || elast == 0 {
+				report("extraData EIP-1559 params must be non-zero (denominator=%d elasticity=%d)", denom, elast)
 			}
-			// Compare timestamp vs compose TOML genesis.l2_time if present
-			if ccfg.Genesis.L2Time != 0 {
-				ts, err := anyToInt64(g.Timestamp)
-				if err != nil {
-					fatalf("%s timestamp parse: %v", genPath, err)
+			if op, ok := g.Config["optimism"].(map[string]any); ok {
+				if want, err := anyToBig(op["eip1559DenominatorCanyon"]); err == nil && want.Uint64() != denom {
+					report("extraData denominator=%d, config.optimism.eip1559DenominatorCanyon=%s", denom, want)
 				}
-				if ts != int64(ccfg.Genesis.L2Time) {
-					fatalf("%s timestamp=%d, want %d", genPath, ts, ccfg.Genesis.L2Time)
+				if want, err := anyToBig(op["eip1559Elasticity"]); err == nil && want.Uint64() != elast {
+					report("extraData elasticity=%d, config.optimism.eip1559Elasticity=%s", elast, want)
 				}
 			}
 		}
 	}
-	fmt.Println("checkgenesis ok")
+	return out
+}
+
+// forkValue returns config[name] as a number, or nil if the fork is unscheduled.
+func forkValue(config map[string]any, name string) (*big.Int, error) {
+	v, ok := config[name]
+	if !ok || v == nil {
+		return nil, nil
+	}
+	n, err := anyToBig(v)
+	if err != nil {
+		return nil, fmt.Errorf("config.%s: %w", name, err)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("config.%s must not be negative", name)
+	}
+	return n, nil
 }
 
-func deriveSlug(identifier string) string {
-	i := strings.LastIndex(identifier, "/")
-	if i >= 0 && i < len(identifier)-1 {
-		return identifier[i+1:]
+func decodeHex(s *string) ([]byte, error) {
+	if s == nil {
+		return nil, nil
 	}
-	return identifier
+	h := strings.TrimSpace(*s)
+	if !strings.HasPrefix(h, "0x") && !strings.HasPrefix(h, "0X") {
+		return nil, fmt.Errorf("missing 0x prefix: %q", h)
+	}
+	return hex.DecodeString(h[2:])
 }
 
-func anyToInt64(v any) (int64, error) {
+func anyToBig(v any) (*big.Int, error) {
 	switch x := v.(type) {
-	case float64:
-		return int64(x), nil
-	case int64:
-		return x, nil
-	case int:
-		return int64(x), nil
+	case nil:
+		return nil, errors.New("missing")
+	case json.Number:
+		n, ok := new(big.Int).SetString(x.String(), 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", x)
+		}
+		return n, nil
 	case string:
 		s := strings.TrimSpace(x)
+		base := 10
 		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
-			// hex string, may be odd-length
-			// fast path: parse as uint64 from hex (without 0x)
-			u, err := strconv.ParseUint(s[2:], 16, 64)
-			if err != nil {
-				// fallback: decode bytes then big-endian
-				b, err2 := hex.DecodeString(strings.TrimPrefix(s, "0x"))
-				if err2 != nil {
-					return 0, err
-				}
-				var u2 uint64
-				for _, by := range b {
-					u2 = (u2 << 8) | uint64(by)
-				}
-				return int64(u2), nil
-			}
-			return int64(u), nil
+			s, base = s[2:], 16
 		}
-		// decimal
-		u, err := strconv.ParseInt(s, 10, 64)
-		if err != nil {
-			return 0, err
+		n, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q", x)
 		}
-		return u, nil
+		return n, nil
 	default:
-		return 0, errors.New("unsupported number type")
+		return nil, errors.New("unsupported number type")
 	}
 }
 
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+const (
+	testL2Time = 1756804404
+	testL1Time = 1633267481
+)
+
+// validGenesis returns a genesis that passes every rule: all block forks
+// and Canyon through Holocene at genesis, with Holocene extraData
+// (denominator 250, elasticity 6) matching config.optimism.
+func validGenesis() map[string]any {
+	config := map[string]any{
+		"chainId":  77777,
+		"optimism": map[string]any{"eip1559Elasticity": 6, "eip1559Denominator": 50, "eip1559DenominatorCanyon": 250},
+	}
+	for _, f := range blockForks {
+		config[f] = 0
+	}
+	for _, f := range []string{"shanghaiTime", "cancunTime", "regolithTime", "canyonTime", "ecotoneTime", "fjordTime", "graniteTime", "holoceneTime"} {
+		config[f] = 0
+	}
+	return map[string]any{
+		"config":        config,
+		"timestamp":     "0x68b6b534",
+		"gasLimit":      "0x2faf080",
+		"baseFeePerGas": "0x3b9aca00",
+		"extraData":     "0x00000000fa00000006",
+		"alloc":         map[string]any{"4200000000000000000000000000000000000016": map[string]any{"balance": "0x0"}},
+	}
+}
+
+func TestCheckGenesis(t *testing.T) {
+	cases := []struct {
+		name string
+		edit func(g, config map[string]any)
+		l1   uint64 // L1 genesis_time; testL1Time when 0
+		want string // substring of a violation; "" for none
+	}{
+		{"valid", func(g, config map[string]any) {}, 0, ""},
+		{"placeholder", func(g, config map[string]any) {
+			for k := range g {
+				if k != "config" && k != "timestamp" {
+					delete(g, k)
+				}
+			}
+			g["config"] = map[string]any{"chainId": 77777}
+		}, 0, ""},
+		{"placeholder fork rules", func(g, config map[string]any) {
+			for k := range g {
+				if k != "config" && k != "timestamp" {
+					delete(g, k)
+				}
+			}
+			g["config"] = map[string]any{"chainId": 77777, "shanghaiTime": 0, "canyonTime": 5}
+		}, 0, "config.canyonTime=5 is before genesis timestamp"},
+		{"placeholder base fee", func(g, config map[string]any) {
+			for k := range g {
+				if k != "config" && k != "timestamp" && k != "baseFeePerGas" {
+					delete(g, k)
+				}
+			}
+			g["config"] = map[string]any{"chainId": 77777}
+		}, 0, "baseFeePerGas set but London is not active"},
+		{"alloc without gasLimit", func(g, config map[string]any) { delete(g, "gasLimit") }, 0, "gasLimit: missing"},
+		{"chain id", func(g, config map[string]any) { config["chainId"] = 1 }, 0, "config.chainId=1, want 77777"},
+		{"timestamp", func(g, config map[string]any) { g["timestamp"] = testL2Time + 1 }, 0, "want l2_time 1756804404"},
+		{"L1 genesis_time", nil, testL2Time, "is not after L1 genesis_time"},
+		{"gas limit", func(g, config map[string]any) { g["gasLimit"] = "0x0" }, 0, "gasLimit must be positive"},
+		{"base fee missing", func(g, config map[string]any) { delete(g, "baseFeePerGas") }, 0, "baseFeePerGas (required with londonBlock=0)"},
+		{"base fee before London", func(g, config map[string]any) {
+			for _, f := range blockForks[10:] {
+				config[f] = 1
+			}
+		}, 0, "baseFeePerGas set but London is not active"},
+		{"long extraData", func(g, config map[string]any) {
+			delete(config, "holoceneTime")
+			g["extraData"] = "0x" + strings.Repeat("00", 33)
+		}, 0, "extraData is 33 bytes, max 32"},
+		{"negative fork", func(g, config map[string]any) { config["berlinBlock"] = -1 }, 0, "config.berlinBlock must not be negative"},
+		{"missing earlier fork", func(g, config map[string]any) { delete(config, "berlinBlock") }, 0, "config.londonBlock is set but earlier fork berlinBlock is not"},
+		{"fork order", func(g, config map[string]any) {
+			config["graniteTime"] = testL2Time + 100
+			config["holoceneTime"] = testL2Time + 50
+		}, 0, "config.holoceneTime=1756804454 activates before earlier fork graniteTime"},
+		{"fork before genesis", func(g, config map[string]any) { config["fjordTime"] = 5 }, 0, "config.fjordTime=5 is before genesis timestamp"},
+		{"paired forks", func(g, config map[string]any) { config["pragueTime"] = 0 }, 0, "config.isthmusTime and config.pragueTime must activate together"},
+		{"paired forks at different times", func(g, config map[string]any) { config["ecotoneTime"] = testL2Time + 10 }, 0, "config.ecotoneTime and config.cancunTime must activate together"},
+		{"Holocene extraData length", func(g, config map[string]any) { g["extraData"] = "0x" }, 0, "extraData must be 9 bytes with version 0"},
+		{"Holocene extraData version", func(g, config map[string]any) { g["extraData"] = "0x01000000fa00000006" }, 0, "extraData must be 9 bytes with version 0"},
+		{"Holocene zero params", func(g, config map[string]any) { g["extraData"] = "0x000000000000000006" }, 0, "must be non-zero"},
+		{"Holocene denominator", func(g, config map[string]any) { g["extraData"] = "0x000000003200000006" }, 0, "extraData denominator=50, config.optimism.eip1559DenominatorCanyon=250"},
+		{"Holocene elasticity", func(g, config map[string]any) { g["extraData"] = "0x00000000fa0000000a" }, 0, "extraData elasticity=10, config.optimism.eip1559Elasticity=6"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := validGenesis()
+			if tc.edit != nil {
+				tc.edit(g, g["config"].(map[string]any))
+			}
+			raw, err := json.Marshal(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ccfg reg.ChainConfig
+			ccfg.ChainID = 77777
+			ccfg.Genesis.L2Time = testL2Time
+			var ncfg reg.NetworkConfig
+			ncfg.L1.GenesisTime = tc.l1
+			if ncfg.L1.GenesisTime == 0 {
+				ncfg.L1.GenesisTime = testL1Time
+			}
+
+			got := checkGenesis(raw, ccfg, ncfg)
+			if tc.want == "" {
+				if len(got) != 0 {
+					t.Fatalf("checkGenesis() = %q, want no violations", got)
+				}
+				return
+			}
+			for _, v := range got {
+				if strings.Contains(v, tc.want) {
+					return
+				}
+			}
+			t.Fatalf("checkGenesis() = %q, want a violation containing %q", got, tc.want)
+		})
+	}
+}
+
+func TestCheckGenesis_Undecodable(t *testing.T) {
+	got := checkGenesis([]byte("{"), reg.ChainConfig{}, reg.NetworkConfig{})
+	if len(got) != 1 || !strings.HasPrefix(got[0], "decode genesis") {
+		t.Fatalf("checkGenesis() = %q, want one decode error", got)
+	}
+}
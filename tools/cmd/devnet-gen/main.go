@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	reg "github.com/compose-network/registry/registry"
+	"github.com/compose-network/registry/tools/internal/toolutil"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultImage is pinned so regenerated devnets stay reproducible; bump it
+// together with the op-node release the devnets run.
+const defaultImage = "us-docker.pkg.dev/oplabs-tools-artifacts/images/op-geth:v1.101511.0"
+
+type composeFile struct {
+	Name     string              `yaml:"name"`
+	Services map[string]*service `yaml:"services"`
+	Volumes  map[string]struct{} `yaml:"volumes,omitempty"`
+}
+
+type service struct {
+	Image      string            `yaml:"image"`
+	Hostname   string            `yaml:"hostname"`
+	Entrypoint []string          `yaml:"entrypoint,omitempty"`
+	Command    []string          `yaml:"command,omitempty"`
+	Ports      []string          `yaml:"ports,omitempty"`
+	Volumes    []string          `yaml:"volumes,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+	Networks   map[string]netCfg `yaml:"networks,omitempty"`
+}
+
+type netCfg struct {
+	Aliases []string `yaml:"aliases,omitempty"`
+}
+
+func main() {
+	var base, network, out, image, tmplPath string
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&network, "network", "", "network slug")
+	flag.StringVar(&out, "out", "", "output directory (default build/devnet/<network>)")
+	flag.StringVar(&image, "image", defaultImage, "L2 execution client image")
+	flag.StringVar(&tmplPath, "template", "", "genesis template for chains whose registry genesis is a placeholder, relative to -base (optional)")
+	flag.Parse()
+	if network == "" {
+		fatalf("devnet-gen: -network is required")
+	}
+	if out == "" {
+		out = filepath.Join("build", "devnet", network)
+	}
+	dir := out
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(base, out)
+	}
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	var tmpl []byte
+	if tmplPath != "" {
+		if tmpl, err = os.ReadFile(filepath.Join(base, tmplPath)); err != nil {
+			fatalf("read template: %v", err)
+		}
+	}
+	files, err := generate(r, network, image, tmpl)
+	if err != nil {
+		fatalf("devnet-gen: %v", err)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := toolutil.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), files[name]); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	fmt.Printf("wrote %s (chains=%d)\n", filepath.Join(dir, composeName), len(names)-1)
+}
+
+const composeName = "docker-compose.yml"
+
+// generate renders the compose file and the genesis of every chain of
+// network, keyed by slash-separated path relative to the output directory.
+// A chain whose registry genesis is a placeholder gets tmpl filled in
+// instead; without tmpl such a chain is an error.
+func generate(r reg.Registry, network, image string, tmpl []byte) (map[string][]byte, error) {
+	n, err := r.GetNetworkBySlug(network)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s: %w", network, err)
+	}
+	chains, err := n.ListChains()
+	if err != nil {
+		return nil, fmt.Errorf("list chains for %s: %w", network, err)
+	}
+
+	files := make(map[string][]byte)
+	cf := composeFile{
+		Name:     "compose-" + network,
+		Services: make(map[string]*service),
+		Volumes:  make(map[string]struct{}),
+	}
+	for _, c := range chains {
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("load %s: %w", c.Identifier(), err)
+		}
+		rpcHost, rpcPort, err := rpcHostPort(cfg.PublicRPC)
+		if err != nil {
+			return nil, fmt.Errorf("%s: public_rpc: %w", c.Identifier(), err)
+		}
+		host := serviceName(c.Slug(), rpcHost, cfg.Sequencer.Host)
+		if _, dup := cf.Services[host]; dup {
+			return nil, fmt.Errorf("%s: service %q already used by another chain", c.Identifier(), host)
+		}
+
+		genesis, err := c.LoadGenesis()
+		if errors.Is(err, reg.ErrGenesisNotFound) {
+			return nil, fmt.Errorf("%s: no genesis in the registry; run genesis-gen first", c.Identifier())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.Identifier(), err)
+		}
+		err = checkGenesis(genesis, cfg.ChainID)
+		if errors.Is(err, errPlaceholder) && tmpl != nil {
+			if genesis, err = fromTemplate(tmpl, cfg); err == nil {
+				err = checkGenesis(genesis, cfg.ChainID)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: genesis: %w", c.Identifier(), err)
+		}
+		files["genesis/"+c.Slug()+".json"] = genesis
+
+		// Container ports only: docker picks free host ports, so chains
+		// sharing a port do not collide; see docker compose port. A
+		// sequencer on a container name resolves to this node (as its
+		// service name or an alias below), so its port is served here too.
+		ports := []string{strconv.Itoa(rpcPort)}
+		seqPort := 0
+		if dockerHost(cfg.Sequencer.Host) && cfg.Sequencer.Port != 0 && cfg.Sequencer.Port != rpcPort {
+			seqPort = cfg.Sequencer.Port
+			ports = append(ports, strconv.Itoa(seqPort))
+		}
+		data := c.Slug() + "-data"
+		cf.Volumes[data] = struct{}{}
+		svc := &service{
+			Image:      image,
+			Hostname:   host,
+			Entrypoint: []string{"/bin/sh", "-c"},
+			Command:    []string{gethScript(cfg.ChainID, rpcPort, seqPort)},
+			Ports:      ports,
+			Volumes: []string{
+				"./genesis/" + c.Slug() + ".json:/genesis.json:ro",
+				data + ":/data",
+			},
+			Labels: map[string]string{
+				"network.compose.chain":    c.Identifier(),
+				"network.compose.chain_id": strconv.FormatUint(cfg.ChainID, 10),
+			},
+		}
+		cf.Services[host] = svc
+
+		// Make any other docker-style name the registry uses for this chain
+		// resolve to its node.
+		var aliases []string
+		for _, h := range []string{rpcHost, cfg.Sequencer.Host} {
+			if h != host && dockerHost(h) && !slices.Contains(aliases, h) {
+				aliases = append(aliases, h)
+			}
+		}
+		if len(aliases) > 0 {
+			svc.Networks = map[string]netCfg{"default": {Aliases: aliases}}
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s: generated from github.com/compose-network/registry by devnet-gen; do not edit.\n", network)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cf); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	_ = enc.Close()
+	files[composeName] = buf.Bytes()
+	return files, nil
+}
+
+// errPlaceholder is returned by checkGenesis for a genesis that carries only
+// chainId and timestamp.
+var errPlaceholder = errors.New("placeholder without gasLimit or alloc; run genesis-gen first or pass -template")
+
+// checkGenesis rejects a genesis geth cannot be initialised from:
+// undecodable JSON, a config.chainId other than chainID, or a placeholder
+// (errPlaceholder).
+func checkGenesis(raw []byte, chainID uint64) error {
+	var g struct {
+		Config struct {
+			ChainID uint64 `json:"chainId"`
+		} `json:"config"`
+		GasLimit json.RawMessage            `json:"gasLimit"`
+		Alloc    map[string]json.RawMessage `json:"alloc"`
+	}
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	if g.Config.ChainID != chainID {
+		return fmt.Errorf("config.chainId = %d, want chain_id %d", g.Config.ChainID, chainID)
+	}
+	if len(g.GasLimit) == 0 || len(g.Alloc) == 0 {
+		return errPlaceholder
+	}
+	return nil
+}
+
+// fromTemplate fills the genesis template tmpl with the chain's chain_id and
+// [genesis].l2_time, as genesis-gen does without predeploys.
+func fromTemplate(tmpl []byte, cfg reg.ChainConfig) ([]byte, error) {
+	if cfg.Genesis.L2Time == 0 {
+		return nil, errors.New("genesis.l2_time is required to fill the template")
+	}
+	var g map[string]any
+	dec := json.NewDecoder(bytes.NewReader(tmpl))
+	dec.UseNumber()
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+	config, ok := g["config"].(map[string]any)
+	if !ok {
+		return nil, errors.New("template has no config object")
+	}
+	config["chainId"] = json.Number(strconv.FormatUint(cfg.ChainID, 10))
+	g["timestamp"] = "0x" + strconv.FormatUint(cfg.Genesis.L2Time, 16)
+	b, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// rpcHostPort splits a chain's public_rpc into host and port (default 8545).
+func rpcHostPort(rpc string) (string, int, error) {
+	u, err := url.Parse(rpc)
+	if err != nil {
+		return "", 0, err
+	}
+	host, port := u.Hostname(), u.Port()
+	if host == "" {
+		return "", 0, fmt.Errorf("no host in %q", rpc)
+	}
+	p := 8545
+	if port != "" {
+		if p, err = strconv.Atoi(port); err != nil {
+			return "", 0, err
+		}
+	}
+	return host, p, nil
+}
+
+// serviceName picks the compose service for a chain's node: the RPC host
+// when it is a docker-style name ("optimism-stack-geth"), so the registry
+// URL resolves inside the compose network, else the sequencer host, else
+// "<chain>-geth".
+func serviceName(slug, rpcHost, seqHost string) string {
+	switch {
+	case dockerHost(rpcHost):
+		return rpcHost
+	case dockerHost(seqHost):
+		return seqHost
+	}
+	return slug + "-geth"
+}
+
+// dockerHost reports whether h is a bare container name rather than a DNS
+// name or IP.
+func dockerHost(h string) bool {
+	return h != "" && h != "localhost" && !strings.Contains(h, ".") && net.ParseIP(h) == nil
+}
+
+// gethScript initialises and starts the node. A non-zero seqPort is served
+// by geth's authenticated RPC, so the registry's sequencer endpoint reaches
+// the node.
+func gethScript(chainID uint64, rpcPort, seqPort int) string {
+	s := fmt.Sprintf("set -e; [ -d /data/geth ] || geth init --datadir=/data --state.scheme=hash /genesis.json; "+
+		"exec geth --datadir=/data --networkid=%d --syncmode=full --gcmode=archive "+
+		"--http --http.addr=0.0.0.0 --http.port=%d --http.vhosts=* --http.api=eth,net,web3,debug "+
+		"--ws --ws.addr=0.0.0.0 --ws.port=8546 --rollup.disabletxpoolgossip", chainID, rpcPort)
+	if seqPort != 0 {
+		s += fmt.Sprintf(" --authrpc.addr=0.0.0.0 --authrpc.port=%d --authrpc.vhosts=*", seqPort)
+	}
+	return s
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.golden")
+
+const (
+	genesisA = `{"config":{"chainId":77777},"timestamp":"0x68b6d434","gasLimit":"0x2faf080","alloc":{"0x4200000000000000000000000000000000000016":{"balance":"0x0"}}}`
+	genesisB = `{"config":{"chainId":88888},"timestamp":"0x68b6d434","gasLimit":"0x2faf080","alloc":{"0x4200000000000000000000000000000000000016":{"balance":"0x0"}}}`
+)
+
+func devnetFS(genesisB string) fstest.MapFS {
+	return fstest.MapFS{
+		"networks/devnet/compose.toml": {Data: []byte("environment = \"devnet\"\n[l1]\nchain_id = 560048\n")},
+		"networks/devnet/rollup-a.toml": {Data: []byte(`chain_id = 77777
+public_rpc = "http://optimism-stack-geth:8545"
+
+[sequencer]
+host = "optimism-stack-geth"
+port = 9898
+`)},
+		"networks/devnet/rollup-b.toml": {Data: []byte(`chain_id = 88888
+public_rpc = "https://rpc-b.example.org"
+
+[sequencer]
+host = "op-b-sequencer"
+port = 9898
+`)},
+		// Plain JSON is accepted under the .json.zst name.
+		"genesis/devnet/rollup-a.json.zst": {Data: []byte(genesisA)},
+		"genesis/devnet/rollup-b.json.zst": {Data: []byte(genesisB)},
+	}
+}
+
+func TestGenerate_Golden(t *testing.T) {
+	r, err := reg.NewFromFS(devnetFS(genesisB))
+	if err != nil {
+		t.Fatalf("NewFromFS() error: %v", err)
+	}
+	files, err := generate(r, "devnet", "op-geth:test", nil)
+	if err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	if got := string(files["genesis/rollup-a.json"]); got != genesisA {
+		t.Fatalf("genesis/rollup-a.json = %s, want %s", got, genesisA)
+	}
+	if len(files) != 3 {
+		t.Fatalf("generate() wrote %d files, want compose file and two genesis files", len(files))
+	}
+	checkGolden(t, "docker-compose.yml.golden", files[composeName])
+}
+
+// TestGenerate_HoodiDev runs against the committed hoodi-dev data, whose
+// genesis files are placeholders, so the template fills them in.
+func TestGenerate_HoodiDev(t *testing.T) {
+	r, err := reg.NewFromDir(filepath.Join("..", "..", "..", "data"))
+	if err != nil {
+		t.Fatalf("NewFromDir() error: %v", err)
+	}
+	if _, err := generate(r, "hoodi-dev", "op-geth:test", nil); !errors.Is(err, errPlaceholder) {
+		t.Fatalf("generate() without template error = %v, want %v", err, errPlaceholder)
+	}
+	tmpl, err := os.ReadFile(filepath.Join("testdata", "genesis-template.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := generate(r, "hoodi-dev", "op-geth:test", tmpl)
+	if err != nil {
+		t.Fatalf("generate() error: %v", err)
+	}
+	for name, id := range map[string]uint64{"rollup-a": 77777, "rollup-b": 88888} {
+		if err := checkGenesis(files["genesis/"+name+".json"], id); err != nil {
+			t.Errorf("genesis/%s.json: %v", name, err)
+		}
+	}
+	checkGolden(t, "hoodi-dev.yml.golden", files[composeName])
+}
+
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run go test -update): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("%s mismatch (run go test -update)\n--- got\n%s\n--- want\n%s", name, got, want)
+	}
+}
+
+func TestGenerate_RejectsUnusableGenesis(t *testing.T) {
+	cases := []struct {
+		name    string
+		genesis string
+		want    string
+	}{
+		{"placeholder", `{"config":{"chainId":88888},"timestamp":1756804404}`, "placeholder"},
+		{"not JSON", "not json", "decode"},
+		{"other chain", strings.Replace(genesisB, "88888", "99999", 1), "want chain_id 88888"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := reg.NewFromFS(devnetFS(tc.genesis))
+			if err != nil {
+				t.Fatalf("NewFromFS() error: %v", err)
+			}
+			_, err = generate(r, "devnet", defaultImage, nil)
+			if err == nil || !strings.Contains(err.Error(), "devnet/rollup-b: genesis") || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("generate() error = %v, want devnet/rollup-b genesis error containing %q", err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	reg "github.com/compose-network/registry/registry"
+	"gopkg.in/yaml.v3"
+)
+
+// deployment is the input of the deployment formats (dotenv, configmap,
+// helm): one network with either one chain or all of its chains.
+type deployment struct {
+	Network string
+	Config  reg.NetworkConfig
+	Chains  []deployedChain
+	// Single is set when a chain identifier was exported; chain keys are
+	// then unprefixed (COMPOSE_CHAIN_ID rather than COMPOSE_ROLLUP_A_CHAIN_ID).
+	Single bool
+}
+
+type deployedChain struct {
+	Slug   string
+	Config reg.ChainConfig
+}
+
+// kv is one exported variable.
+type kv struct {
+	Key, Value string
+}
+
+// loadDeployment resolves a network slug or a "<network>/<chain>" identifier.
+func loadDeployment(r reg.Registry, arg string) (deployment, error) {
+	var d deployment
+	var chains []reg.Chain
+	if strings.Contains(arg, "/") {
+		c, err := r.GetChainByIdentifier(arg)
+		if err != nil {
+			return d, err
+		}
+		chains, d.Single = []reg.Chain{c}, true
+		d.Network = c.Network().Slug()
+	} else {
+		n, err := r.GetNetworkBySlug(arg)
+		if err != nil {
+			return d, err
+		}
+		if chains, err = n.ListChains(); err != nil {
+			return d, err
+		}
+		d.Network = n.Slug()
+	}
+	n, err := r.GetNetworkBySlug(d.Network)
+	if err != nil {
+		return d, err
+	}
+	if d.Config, err = n.LoadConfig(); err != nil {
+		return d, fmt.Errorf("load network %s: %w", d.Network, err)
+	}
+	for _, c := range chains {
+		cfg, err := c.LoadConfig()
+		if err != nil {
+			return d, fmt.Errorf("load %s: %w", c.Identifier(), err)
+		}
+		d.Chains = append(d.Chains, deployedChain{Slug: c.Slug(), Config: cfg})
+	}
+	return d, nil
+}
+
+// name is the resource name used for the ConfigMap: compose-<network>[-<chain>].
+func (d deployment) name() string {
+	if d.Single {
+		return "compose-" + d.Network + "-" + d.Chains[0].Slug
+	}
+	return "compose-" + d.Network
+}
+
+// vars returns the flat variable set shared by dotenv and configmap. Keys
+// are COMPOSE_<FIELD> for the network and a single chain, and
+// COMPOSE_<CHAIN>_<FIELD> per chain when a whole network is exported.
+// Every key is emitted even when its value is empty so consumers can rely
+// on the set.
+func (d deployment) vars() []kv {
+	n := d.Config
+	out := []kv{
+		{"COMPOSE_NETWORK", d.Network},
+		{"COMPOSE_L1_CHAIN_ID", strconv.FormatUint(n.L1.ChainID, 10)},
+		{"COMPOSE_L1_RPC_URL", n.L1.PublicRPC},
+		{"COMPOSE_L1_EXPLORER_URL", n.L1.Explorer},
+		{"COMPOSE_SUPERBLOCK_CONTRACT_ADDRESS", string(n.Publisher.SuperblockContract)},
+		{"COMPOSE_DISPUTE_GAME_FACTORY_ADDRESS", string(n.Publisher.DisputeGameFactory)},
+	}
+	if !d.Single {
+		slugs := make([]string, 0, len(d.Chains))
+		for _, c := range d.Chains {
+			slugs = append(slugs, c.Slug)
+		}
+		out = append(out, kv{"COMPOSE_CHAINS", strings.Join(slugs, ",")})
+	}
+	for _, c := range d.Chains {
+		p := "COMPOSE_"
+		if !d.Single {
+			p += envName(c.Slug) + "_"
+		}
+		cfg := c.Config
+		out = append(out,
+			kv{p + "IDENTIFIER", d.Network + "/" + c.Slug},
+			kv{p + "CHAIN_ID", strconv.FormatUint(cfg.ChainID, 10)},
+			kv{p + "CHAIN_NAME", cfg.Name},
+			kv{p + "RPC_URL", cfg.PublicRPC},
+			kv{p + "EXPLORER_URL", cfg.Explorer},
+			kv{p + "MAILBOX_ADDRESS", string(cfg.Addresses.Mailbox)},
+			kv{p + "SEQUENCER_HOST", cfg.Sequencer.Host},
+			kv{p + "SEQUENCER_PORT", strconv.Itoa(cfg.Sequencer.Port)},
+			kv{p + "GENESIS_L2_TIME", strconv.FormatUint(cfg.Genesis.L2Time, 10)},
+		)
+	}
+	return out
+}
+
+// envName upper-cases a slug and replaces anything outside [A-Z0-9] with "_".
+func envName(slug string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, slug)
+}
+
+func header(d deployment) string {
+	what := d.Network
+	if d.Single {
+		what += "/" + d.Chains[0].Slug
+	}
+	return "# " + what + ": generated from github.com/compose-network/registry; do not edit.\n"
+}
+
+// renderDotenv writes KEY=value lines, quoting values that are not plain
+// words; see dotenvQuote.
+func renderDotenv(w io.Writer, d deployment) error {
+	var b strings.Builder
+	b.WriteString(header(d))
+	for _, v := range d.vars() {
+		fmt.Fprintf(&b, "%s=%s\n", v.Key, dotenvQuote(v.Value))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotenvQuote quotes val so that shells and docker compose read it back
+// verbatim. Single quotes suppress $ and ` expansion; a value containing a
+// single quote is double-quoted with ", \, $ and ` escaped instead.
+func dotenvQuote(val string) string {
+	switch {
+	case val != "" && !strings.ContainsAny(val, " \t\n\"'#$\\`="):
+		return val
+	case !strings.ContainsAny(val, "'\n"):
+		return "'" + val + "'"
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range val {
+		switch r {
+		case '"', '\\', '$', '`':
+			b.WriteByte('\\')
+		case '\n':
+			b.WriteString(`\n`)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+type configMap struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Data map[string]string `yaml:"data"`
+}
+
+// renderConfigMap writes a v1 ConfigMap whose data is the dotenv variable
+// set, so it can be mounted with envFrom. name overrides the default name.
+func renderConfigMap(w io.Writer, d deployment, name string) error {
+	cm := configMap{APIVersion: "v1", Kind: "ConfigMap", Data: make(map[string]string)}
+	cm.Metadata.Name = name
+	if cm.Metadata.Name == "" {
+		cm.Metadata.Name = d.name()
+	}
+	for _, v := range d.vars() {
+		cm.Data[v.Key] = v.Value
+	}
+	return writeYAML(w, header(d), cm)
+}
+
+type helmChain struct {
+	Identifier     string `yaml:"identifier"`
+	ChainID        uint64 `yaml:"chainId"`
+	Name           string `yaml:"name"`
+	RPCURL         string `yaml:"rpcUrl"`
+	ExplorerURL    string `yaml:"explorerUrl"`
+	MailboxAddress string `yaml:"mailboxAddress"`
+	Sequencer      struct {
+		Host string `yaml:"host"`
+		Port int    `yaml:"port"`
+	} `yaml:"sequencer"`
+	Genesis struct {
+		L2Time uint64 `yaml:"l2Time"`
+	} `yaml:"genesis"`
+}
+
+type helmValues struct {
+	Compose struct {
+		Network string `yaml:"network"`
+		L1      struct {
+			ChainID     uint64 `yaml:"chainId"`
+			RPCURL      string `yaml:"rpcUrl"`
+			ExplorerURL string `yaml:"explorerUrl"`
+		} `yaml:"l1"`
+		Publisher struct {
+			SuperblockContract string `yaml:"superblockContract"`
+			DisputeGameFactory string `yaml:"disputeGameFactory"`
+		} `yaml:"publisher"`
+		// Chain is set for a chain export, Chains (keyed by slug) for a
+		// network export.
+		Chain  *helmChain            `yaml:"chain,omitempty"`
+		Chains map[string]*helmChain `yaml:"chains,omitempty"`
+	} `yaml:"compose"`
+}
+
+// renderHelm writes a values fragment under a top-level "compose" key.
+func renderHelm(w io.Writer, d deployment) error {
+	var v helmValues
+	c := &v.Compose
+	c.Network = d.Network
+	c.L1.ChainID = d.Config.L1.ChainID
+	c.L1.RPCURL = d.Config.L1.PublicRPC
+	c.L1.ExplorerURL = d.Config.L1.Explorer
+	c.Publisher.SuperblockContract = string(d.Config.Publisher.SuperblockContract)
+	c.Publisher.DisputeGameFactory = string(d.Config.Publisher.DisputeGameFactory)
+	for _, dc := range d.Chains {
+		hc := &helmChain{
+			Identifier:     d.Network + "/" + dc.Slug,
+			ChainID:        dc.Config.ChainID,
+			Name:           dc.Config.Name,
+			RPCURL:         dc.Config.PublicRPC,
+			ExplorerURL:    dc.Config.Explorer,
+			MailboxAddress: string(dc.Config.Addresses.Mailbox),
+		}
+		hc.Sequencer.Host = dc.Config.Sequencer.Host
+		hc.Sequencer.Port = dc.Config.Sequencer.Port
+		hc.Genesis.L2Time = dc.Config.Genesis.L2Time
+		if d.Single {
+			c.Chain = hc
+			continue
+		}
+		if c.Chains == nil {
+			c.Chains = make(map[string]*helmChain)
+		}
+		c.Chains[dc.Slug] = hc
+	}
+	return writeYAML(w, header(d), v)
+}
+
+// writeYAML encodes v after header. String values are always double-quoted:
+// addresses like 0x54e6…2C are valid YAML hex integers and must not be read
+// back as numbers.
+func writeYAML(w io.Writer, header string, v any) error {
+	var doc yaml.Node
+	if err := doc.Encode(v); err != nil {
+		return err
+	}
+	quoteStrings(&doc)
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func quoteStrings(n *yaml.Node) {
+	for i, c := range n.Content {
+		if n.Kind == yaml.MappingNode && i%2 == 0 {
+			continue // key
+		}
+		if c.Kind == yaml.ScalarNode && c.Tag == "!!str" {
+			c.Style = yaml.DoubleQuotedStyle
+		}
+		quoteStrings(c)
+	}
+}
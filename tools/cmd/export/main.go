@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+// endpoint is one exported RPC target: an L2 chain or a network's L1.
+type endpoint struct {
+	Alias       string // <network>-<chain> or <network>-l1
+	ChainID     uint64
+	PublicRPC   string
+	Explorer    string
+	ExplorerAPI string
+}
+
+// options carries the format-specific flags.
+type options struct {
+	KeyEnv string // foundry: env var referenced for explorer API keys
+	Name   string // configmap: metadata.name override
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `usage: export [flags] [network | network/chain ...]
+
+Renders RPC/explorer config for contract tooling (foundry, hardhat) for the
+given networks (L1 plus every chain) or chain identifiers; with no arguments
+every network is exported. The deployment formats (dotenv, configmap, helm)
+take exactly one network or chain identifier.
+
+`)
+	flag.PrintDefaults()
+}
+
+func main() {
+	var base, format, out string
+	var o options
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&format, "format", "foundry", "output format: foundry, hardhat, dotenv, configmap, helm")
+	flag.StringVar(&out, "out", "", "output file (default stdout)")
+	flag.StringVar(&o.KeyEnv, "api-key-env", "ETHERSCAN_API_KEY", "env var referenced for explorer API keys (foundry)")
+	flag.StringVar(&o.Name, "name", "", "ConfigMap name (default compose-<network>[-<chain>])")
+	flag.Usage = usage
+	flag.Parse()
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := render(&buf, r, format, flag.Args(), o); err != nil {
+		fatalf("export: %v", err)
+	}
+	if out == "" {
+		_, _ = os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0o644); err != nil {
+		fatalf("write %s: %v", out, err)
+	}
+}
+
+// render writes args from r in the given format.
+func render(w io.Writer, r reg.Registry, format string, args []string, o options) error {
+	switch format {
+	case "foundry", "hardhat":
+		eps, err := collect(r, args)
+		if err != nil {
+			return err
+		}
+		if format == "foundry" {
+			return renderFoundry(w, eps, o.KeyEnv)
+		}
+		return renderHardhat(w, eps)
+	case "dotenv", "configmap", "helm":
+		if len(args) != 1 {
+			return fmt.Errorf("format %s takes exactly one network or chain identifier", format)
+		}
+		d, err := loadDeployment(r, args[0])
+		if err != nil {
+			return err
+		}
+		switch format {
+		case "dotenv":
+			return renderDotenv(w, d)
+		case "configmap":
+			return renderConfigMap(w, d, o.Name)
+		}
+		return renderHelm(w, d)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// collect resolves args to endpoints in argument order. A bare slug selects
+// a network's L1 and all its chains; "<network>/<chain>" selects one chain.
+func collect(r reg.Registry, args []string) ([]endpoint, error) {
+	if len(args) == 0 {
+		nets, err := r.ListNetworks()
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range nets {
+			args = append(args, n.Slug())
+		}
+	}
+	var eps []endpoint
+	seen := make(map[string]bool)
+	add := func(e endpoint) {
+		if !seen[e.Alias] {
+			seen[e.Alias] = true
+			eps = append(eps, e)
+		}
+	}
+	for _, arg := range args {
+		if strings.Contains(arg, "/") {
+			c, err := r.GetChainByIdentifier(arg)
+			if err != nil {
+				return nil, err
+			}
+			e, err := chainEndpoint(c)
+			if err != nil {
+				return nil, err
+			}
+			add(e)
+			continue
+		}
+		n, err := r.GetNetworkBySlug(arg)
+		if err != nil {
+			return nil, err
+		}
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("load network %s: %w", n.Slug(), err)
+		}
+		add(endpoint{
+			Alias:       n.Slug() + "-l1",
+			ChainID:     ncfg.L1.ChainID,
+			PublicRPC:   ncfg.L1.PublicRPC,
+			Explorer:    ncfg.L1.Explorer,
+			ExplorerAPI: ncfg.L1.ExplorerAPI,
+		})
+		chains, err := n.ListChains()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chains {
+			e, err := chainEndpoint(c)
+			if err != nil {
+				return nil, err
+			}
+			add(e)
+		}
+	}
+	return eps, nil
+}
+
+func chainEndpoint(c reg.Chain) (endpoint, error) {
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return endpoint{}, fmt.Errorf("load %s: %w", c.Identifier(), err)
+	}
+	return endpoint{
+		Alias:       c.Network().Slug() + "-" + c.Slug(),
+		ChainID:     cfg.ChainID,
+		PublicRPC:   cfg.PublicRPC,
+		Explorer:    cfg.Explorer,
+		ExplorerAPI: cfg.ExplorerAPI,
+	}, nil
+}
+
+// renderFoundry writes [rpc_endpoints] and [etherscan] tables for foundry.toml.
+// Chains without a known explorer API are left out of [etherscan].
+func renderFoundry(w io.Writer, eps []endpoint, keyEnv string) error {
+	var b strings.Builder
+	b.WriteString("# Generated from github.com/compose-network/registry; do not edit.\n\n")
+	b.WriteString("[rpc_endpoints]\n")
+	for _, e := range eps {
+		if e.PublicRPC != "" {
+			fmt.Fprintf(&b, "%s = %q\n", e.Alias, e.PublicRPC)
+		}
+	}
+	b.WriteString("\n[etherscan]\n")
+	for _, e := range eps {
+		if e.ExplorerAPI != "" {
+			fmt.Fprintf(&b, "%s = { key = %q, chain = %d, url = %q }\n", e.Alias, "${"+keyEnv+"}", e.ChainID, e.ExplorerAPI)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type hardhatNetwork struct {
+	URL     string `json:"url"`
+	ChainID uint64 `json:"chainId"`
+}
+
+type hardhatCustomChain struct {
+	Network string `json:"network"`
+	ChainID uint64 `json:"chainId"`
+	URLs    struct {
+		APIURL     string `json:"apiURL"`
+		BrowserURL string `json:"browserURL"`
+	} `json:"urls"`
+}
+
+// renderHardhat writes a JSON object to spread into hardhat.config:
+// "networks" for the RPCs and "etherscan.customChains" for verification.
+func renderHardhat(w io.Writer, eps []endpoint) error {
+	var cfg struct {
+		Networks  map[string]hardhatNetwork `json:"networks"`
+		Etherscan struct {
+			CustomChains []hardhatCustomChain `json:"customChains"`
+		} `json:"etherscan"`
+	}
+	cfg.Networks = make(map[string]hardhatNetwork)
+	cfg.Etherscan.CustomChains = []hardhatCustomChain{}
+	for _, e := range eps {
+		if e.PublicRPC != "" {
+			cfg.Networks[e.Alias] = hardhatNetwork{URL: e.PublicRPC, ChainID: e.ChainID}
+		}
+		if e.ExplorerAPI != "" {
+			cc := hardhatCustomChain{Network: e.Alias, ChainID: e.ChainID}
+			cc.URLs.APIURL = e.ExplorerAPI
+			cc.URLs.BrowserURL = strings.TrimSuffix(e.Explorer, "/")
+			cfg.Etherscan.CustomChains = append(cfg.Etherscan.CustomChains, cc)
+		}
+	}
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
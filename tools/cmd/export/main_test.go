@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.golden")
+
+func TestRender_Golden(t *testing.T) {
+	r, err := reg.NewFromDir(filepath.Join("testdata", "data"))
+	if err != nil {
+		t.Fatalf("NewFromDir() error: %v", err)
+	}
+	cases := []struct {
+		golden string
+		format string
+		args   []string
+		opts   options
+	}{
+		{"foundry.golden", "foundry", []string{"testnet"}, options{KeyEnv: "ETHERSCAN_API_KEY"}},
+		{"hardhat.golden", "hardhat", []string{"testnet/rollup-a", "testnet/rollup-b"}, options{}},
+		{"network.env.golden", "dotenv", []string{"testnet"}, options{}},
+		{"chain.env.golden", "dotenv", []string{"testnet/rollup-a"}, options{}},
+		{"network.configmap.golden", "configmap", []string{"testnet"}, options{}},
+		{"chain.configmap.golden", "configmap", []string{"testnet/rollup-b"}, options{Name: "rollup-b-env"}},
+		{"network.helm.golden", "helm", []string{"testnet"}, options{}},
+		{"chain.helm.golden", "helm", []string{"testnet/rollup-a"}, options{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.golden, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render(&buf, r, tc.format, tc.args, tc.opts); err != nil {
+				t.Fatalf("render(%s, %v) error: %v", tc.format, tc.args, err)
+			}
+			path := filepath.Join("testdata", tc.golden)
+			if *update {
+				if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden (run go test -update): %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("%s mismatch (run go test -update)\n--- got\n%s\n--- want\n%s", tc.golden, buf.Bytes(), want)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	r, err := reg.NewFromDir(filepath.Join("testdata", "data"))
+	if err != nil {
+		t.Fatalf("NewFromDir() error: %v", err)
+	}
+	cases := []struct {
+		name string
+		args []string
+		want []string // aliases in order; nil for an error
+	}{
+		{"all networks", nil, []string{"testnet-l1", "testnet-rollup-a", "testnet-rollup-b"}},
+		{"network", []string{"testnet"}, []string{"testnet-l1", "testnet-rollup-a", "testnet-rollup-b"}},
+		{"argument order, deduplicated", []string{"testnet/rollup-b", "testnet", "testnet/rollup-a"}, []string{"testnet-rollup-b", "testnet-l1", "testnet-rollup-a"}},
+		{"unknown network", []string{"mainnet"}, nil},
+		{"unknown chain", []string{"testnet/rollup-c"}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			eps, err := collect(r, tc.args)
+			if tc.want == nil {
+				if err == nil {
+					t.Fatalf("collect(%v) = %v, want error", tc.args, eps)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("collect(%v) error: %v", tc.args, err)
+			}
+			var got []string
+			for _, e := range eps {
+				got = append(got, e.Alias)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("collect(%v) aliases = %v, want %v", tc.args, got, tc.want)
+			}
+		})
+	}
+
+	eps, err := collect(r, []string{"testnet/rollup-a"})
+	if err != nil {
+		t.Fatalf("collect() error: %v", err)
+	}
+	want := endpoint{
+		Alias:       "testnet-rollup-a",
+		ChainID:     90001,
+		PublicRPC:   "https://rpc-a.example.org",
+		Explorer:    "https://explorer-a.example.org/",
+		ExplorerAPI: "https://explorer-a.example.org/api",
+	}
+	if len(eps) != 1 || eps[0] != want {
+		t.Fatalf("collect(testnet/rollup-a) = %+v, want [%+v]", eps, want)
+	}
+}
+
+func TestRender_DeploymentFormatsTakeOneTarget(t *testing.T) {
+	r, err := reg.NewFromDir(filepath.Join("testdata", "data"))
+	if err != nil {
+		t.Fatalf("NewFromDir() error: %v", err)
+	}
+	for _, format := range []string{"dotenv", "configmap", "helm"} {
+		err := render(&bytes.Buffer{}, r, format, []string{"testnet/rollup-a", "testnet/rollup-b"}, options{})
+		if err == nil || !strings.Contains(err.Error(), "exactly one") {
+			t.Fatalf("%s with two targets: err = %v, want exactly-one error", format, err)
+		}
+	}
+}
+
+func TestDotenvQuote(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"rollup-a", "rollup-a"},
+		{"https://rpc.example.org/v1?key=abc", "'https://rpc.example.org/v1?key=abc'"},
+		{"", "''"},
+		{"Rollup A", "'Rollup A'"},
+		{"pa$$word", "'pa$$word'"},
+		{"`id`", "'`id`'"},
+		{"it's $HOME", `"it's \$HOME"`},
+		{`a"b\c`, `'a"b\c'`},
+		{"line\nbreak", `"line\nbreak"`},
+	}
+	for _, tc := range cases {
+		if got := dotenvQuote(tc.in); got != tc.want {
+			t.Errorf("dotenvQuote(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+	}
+}
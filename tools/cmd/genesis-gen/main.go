@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/compose-network/registry/internal/tomledit"
+	reg "github.com/compose-network/registry/registry"
+	"github.com/compose-network/registry/tools/internal/toolutil"
+)
+
+// predeploy is one *.json artifact in the -predeploys directory.
+// Code may be given directly or as a forge artifact's deployedBytecode.
+type predeploy struct {
+	Address          string            `json:"address"`
+	Code             string            `json:"code"`
+	Storage          map[string]string `json:"storage"`
+	Balance          string            `json:"balance"`
+	Nonce            string            `json:"nonce"`
+	DeployedBytecode struct {
+		Object string `json:"object"`
+	} `json:"deployedBytecode"`
+}
+
+func main() {
+	var base, network, chain, tmplPath, predeployDir, out string
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&network, "network", "", "network slug")
+	flag.StringVar(&chain, "chain", "", "chain slug")
+	flag.StringVar(&tmplPath, "template", "", "genesis template, relative to -base (required)")
+	flag.StringVar(&predeployDir, "predeploys", "", "predeploy artifacts dir, relative to -base (default none)")
+	flag.StringVar(&out, "out", "", "write to this path instead of the content-addressed store (no TOML update)")
+	flag.Parse()
+	if network == "" || chain == "" || tmplPath == "" {
+		fatalf("genesis-gen: -network, -chain and -template are required")
+	}
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	c, err := r.GetChainByIdentifier(network + "/" + chain)
+	if err != nil {
+		fatalf("lookup %s/%s: %v", network, chain, err)
+	}
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		fatalf("load %s: %v", c.Identifier(), err)
+	}
+	if cfg.ChainID == 0 || cfg.Genesis.L2Time == 0 {
+		fatalf("%s: chain_id and genesis.l2_time are required", c.Identifier())
+	}
+
+	tmpl, err := os.ReadFile(filepath.Join(base, tmplPath))
+	if err != nil {
+		fatalf("read template: %v", err)
+	}
+	var predeploys []predeploy
+	if predeployDir != "" {
+		if predeploys, err = loadPredeploys(filepath.Join(base, predeployDir)); err != nil {
+			fatalf("load predeploys: %v", err)
+		}
+	}
+	raw, err := render(tmpl, cfg, predeploys)
+	if err != nil {
+		fatalf("render %s: %v", c.Identifier(), err)
+	}
+	blob, err := toolutil.Compress(raw)
+	if err != nil {
+		fatalf("compress: %v", err)
+	}
+
+	ref := reg.GenesisRef(raw)
+	custom := out != ""
+	if !custom {
+		p, err := reg.GenesisBlobPath(ref)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		out = filepath.Join("data", filepath.FromSlash(p))
+	}
+	dest := filepath.Join(base, out)
+	if err := toolutil.WriteFile(dest, blob); err != nil {
+		fatalf("%v", err)
+	}
+	fmt.Printf("wrote %s (%s, chain_id=%d, l2_time=%d, predeploys=%d)\n", dest, ref, cfg.ChainID, cfg.Genesis.L2Time, len(predeploys))
+
+	if custom {
+		return
+	}
+	prev, err := c.GenesisPath()
+	if err != nil {
+		fatalf("%v", err)
+	}
+	tomlPath := filepath.Join(base, "data", "networks", network, chain+".toml")
+	if err := setGenesisRef(tomlPath, ref); err != nil {
+		fatalf("update %s: %v", tomlPath, err)
+	}
+	fmt.Printf("updated %s ([genesis].file = %q)\n", tomlPath, ref)
+	removed, err := toolutil.PruneGenesis(filepath.Join(base, "data"), prev)
+	if err != nil {
+		fatalf("prune %s: %v", prev, err)
+	}
+	for _, p := range removed {
+		fmt.Printf("removed %s (no longer referenced)\n", filepath.Join(base, "data", filepath.FromSlash(p)))
+	}
+}
+
+// setGenesisRef points [genesis].file in a chain TOML at ref, editing the
+// file in place so comments and layout survive.
+func setGenesisRef(path, ref string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	text, changed := tomledit.Set(string(b), "genesis", "file", strconv.Quote(ref))
+	if !changed {
+		return nil
+	}
+	return os.WriteFile(path, []byte(text), 0o644)
+}
+
+// render fills the template with the chain's id and genesis time and merges
+// the predeploys into alloc. Output is stable: object keys are sorted by
+// encoding/json and numbers keep their template spelling.
+func render(tmpl []byte, cfg reg.ChainConfig, predeploys []predeploy) ([]byte, error) {
+	var g map[string]any
+	dec := json.NewDecoder(bytes.NewReader(tmpl))
+	dec.UseNumber()
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+	config, ok := g["config"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("template has no config object")
+	}
+	config["chainId"] = json.Number(strconv.FormatUint(cfg.ChainID, 10))
+	g["timestamp"] = "0x" + strconv.FormatUint(cfg.Genesis.L2Time, 16)
+
+	alloc, _ := g["alloc"].(map[string]any)
+	if alloc == nil {
+		alloc = make(map[string]any)
+	}
+	normalized := make(map[string]any, len(alloc)+len(predeploys))
+	for addr, acct := range alloc {
+		key, err := allocKey(addr)
+		if err != nil {
+			return nil, fmt.Errorf("template alloc: %w", err)
+		}
+		normalized[key] = acct
+	}
+	for _, p := range predeploys {
+		key, err := allocKey(p.Address)
+		if err != nil {
+			return nil, fmt.Errorf("predeploy: %w", err)
+		}
+		if _, dup := normalized[key]; dup {
+			return nil, fmt.Errorf("predeploy 0x%s: address already allocated", key)
+		}
+		code := p.Code
+		if code == "" {
+			code = p.DeployedBytecode.Object
+		}
+		acct := map[string]any{"balance": toolutil.OrDefault(p.Balance, "0x0")}
+		if code != "" {
+			acct["code"] = code
+		}
+		if p.Nonce != "" {
+			acct["nonce"] = p.Nonce
+		}
+		if len(p.Storage) > 0 {
+			acct["storage"] = p.Storage
+		}
+		normalized[key] = acct
+	}
+	g["alloc"] = normalized
+
+	b, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
+
+// loadPredeploys reads every *.json artifact in dir in name order.
+func loadPredeploys(dir string) ([]predeploy, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	out := make([]predeploy, 0, len(names))
+	for _, name := range names {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		var p predeploy
+		if err := json.Unmarshal(b, &p); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", name, err)
+		}
+		if p.Address == "" {
+			return nil, fmt.Errorf("%s: address required", name)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// allocKey normalizes an address to the lowercase, unprefixed form used as
+// alloc keys in the committed genesis files.
+func allocKey(addr string) (string, error) {
+	s := strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(addr), "0x"), "0X"))
+	if len(s) != 40 || strings.Trim(s, "0123456789abcdef") != "" {
+		return "", fmt.Errorf("invalid address %q", addr)
+	}
+	return s, nil
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
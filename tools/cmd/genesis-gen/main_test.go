@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	reg "github.com/compose-network/registry/registry"
+	"github.com/compose-network/registry/tools/internal/toolutil"
+	"github.com/klauspost/compress/zstd"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.golden")
+
+const template = `{
+  "config": {"chainId": 0, "londonBlock": 0, "optimism": {"eip1559Elasticity": 6}},
+  "gasLimit": "0x2faf080",
+  "baseFeePerGas": "0x3b9aca00",
+  "alloc": {
+    "0x4200000000000000000000000000000000000016": {"balance": "0x0", "code": "0x60"},
+    "0XAAAA000000000000000000000000000000000001": {"balance": "0x1"}
+  }
+}`
+
+func TestRender_Deterministic(t *testing.T) {
+	var cfg reg.ChainConfig
+	cfg.ChainID = 77777
+	cfg.Genesis.L2Time = 1756804404
+	mailbox := predeploy{Address: "0x248721a59a2756E579026aDA017bd9B6adFe3e57", Storage: map[string]string{"0x00": "0x01"}}
+	mailbox.DeployedBytecode.Object = "0x6080"
+	bridge := predeploy{Address: "0x54e692F4e290409035a9cC6A3d55eB047c82112C", Code: "0x6001", Balance: "0x10", Nonce: "0x1"}
+
+	raw, err := render([]byte(template), cfg, []predeploy{mailbox, bridge})
+	if err != nil {
+		t.Fatalf("render() error: %v", err)
+	}
+	again, err := render([]byte(template), cfg, []predeploy{bridge, mailbox})
+	if err != nil {
+		t.Fatalf("render() error: %v", err)
+	}
+	if !bytes.Equal(raw, again) {
+		t.Fatalf("render() depends on predeploy order:\n%s\n---\n%s", raw, again)
+	}
+
+	blob, err := toolutil.Compress(raw)
+	if err != nil {
+		t.Fatalf("Compress() error: %v", err)
+	}
+	for range 3 {
+		b, err := toolutil.Compress(raw)
+		if err != nil {
+			t.Fatalf("Compress() error: %v", err)
+		}
+		if !bytes.Equal(b, blob) {
+			t.Fatal("Compress() output differs between runs")
+		}
+	}
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	if b, err := dec.DecodeAll(blob, nil); err != nil || !bytes.Equal(b, raw) {
+		t.Fatalf("compressed genesis does not round-trip (err %v)", err)
+	}
+
+	golden := filepath.Join("testdata", "genesis.golden")
+	if *update {
+		if err := os.WriteFile(golden, raw, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("read golden (run go test -update): %v", err)
+	}
+	if !bytes.Equal(raw, want) {
+		t.Fatalf("render() mismatch (run go test -update)\n--- got\n%s\n--- want\n%s", raw, want)
+	}
+}
+
+func TestRender_Errors(t *testing.T) {
+	cases := []struct {
+		name, tmpl string
+		predeploys []predeploy
+		want       string
+	}{
+		{"no config", `{"alloc": {}}`, nil, "no config object"},
+		{"bad template alloc", `{"config": {}, "alloc": {"0x12": {}}}`, nil, "template alloc"},
+		{"predeploy over template", template, []predeploy{{Address: "0x4200000000000000000000000000000000000016"}}, "already allocated"},
+		{"bad predeploy address", template, []predeploy{{Address: "mailbox"}}, "invalid address"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := render([]byte(tc.tmpl), reg.ChainConfig{ChainID: 1}, tc.predeploys)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("render() error = %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetGenesisRef(t *testing.T) {
+	const chain = "chain_id = 77777\n\n[genesis] # written by genesis-gen\nl2_time = 1756804404\n\n[sequencer]\nport = 9898\n"
+	path := filepath.Join(t.TempDir(), "rollup-a.toml")
+	if err := os.WriteFile(path, []byte(chain), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, ref := range []string{"sha256:aa", "sha256:bb"} {
+		if err := setGenesisRef(path, ref); err != nil {
+			t.Fatalf("setGenesisRef(%s) error: %v", ref, err)
+		}
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(chain, "l2_time = 1756804404\n", "l2_time = 1756804404\nfile = \"sha256:bb\"\n", 1)
+	if string(b) != want {
+		t.Fatalf("chain TOML =\n%s\nwant\n%s", b, want)
+	}
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/compose-network/registry/internal/tomledit"
+	reg "github.com/compose-network/registry/registry"
+	"github.com/compose-network/registry/tools/internal/toolutil"
+)
+
+// deployerState is the subset of op-deployer's state.json we read. Chain
+// deployments are kept as raw maps because address keys changed spelling
+// across op-deployer releases ("disputeGameFactoryProxyAddress" vs
+// "DisputeGameFactoryProxy").
+type deployerState struct {
+	AppliedIntent struct {
+		L1ChainID uint64 `json:"l1ChainID"`
+	} `json:"appliedIntent"`
+	OpChainDeployments []map[string]json.RawMessage `json:"opChainDeployments"`
+}
+
+// deployerIntent is the subset of op-deployer's intent.toml we read.
+type deployerIntent struct {
+	L1ChainID uint64 `toml:"l1ChainID"`
+}
+
+// edit is one value to set in a TOML file; table "" is the top level.
+type edit struct {
+	table, key, value string
+}
+
+const chainSkeleton = `name = ""
+public_rpc = ""
+explorer = ""
+chain_id = 0
+
+[native_currency]
+name = "Ether"
+symbol = "ETH"
+decimals = 18
+
+[addresses]
+
+[genesis]
+
+[sequencer]
+host = ""
+port = 9898
+auth_pubkeys = []
+`
+
+const networkSkeleton = `name = ""
+environment = ""
+
+[l1]
+chain_id = 0
+public_rpc = ""
+explorer = ""
+
+[publisher]
+superblock_contract = ""
+dispute_game_factory = ""
+auth_pubkeys = []
+`
+
+func main() {
+	var base, statePath, intentPath, genesisPath, network, chain, name, rpc, explorer, env string
+	var chainID uint64
+	var force, dryRun bool
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&statePath, "state", "", "op-deployer state.json")
+	flag.StringVar(&intentPath, "intent", "", "op-deployer intent.toml (optional; l1ChainID)")
+	flag.StringVar(&genesisPath, "genesis", "", "L2 genesis.json from op-deployer inspect genesis (optional)")
+	flag.StringVar(&network, "network", "", "network slug")
+	flag.StringVar(&env, "environment", string(reg.EnvTestnet), "network environment: mainnet, testnet or devnet (new networks only)")
+	flag.StringVar(&chain, "chain", "", "chain slug")
+	flag.Uint64Var(&chainID, "chain-id", 0, "chain to import when the state holds several")
+	flag.StringVar(&name, "name", "", "chain display name (default: chain slug, new chains only)")
+	flag.StringVar(&rpc, "public-rpc", "", "chain public RPC URL")
+	flag.StringVar(&explorer, "explorer", "", "chain explorer URL")
+	flag.BoolVar(&force, "force", false, "overwrite values that conflict with existing data")
+	flag.BoolVar(&dryRun, "dry-run", false, "show the diff without writing")
+	flag.Parse()
+	if statePath == "" || network == "" || chain == "" {
+		fatalf("import: -state, -network and -chain are required")
+	}
+	if !reg.Environment(env).Valid() {
+		fatalf("import: -environment must be mainnet, testnet or devnet, got %q", env)
+	}
+
+	var st deployerState
+	b, err := os.ReadFile(statePath)
+	if err != nil {
+		fatalf("read state: %v", err)
+	}
+	if err := json.Unmarshal(b, &st); err != nil {
+		fatalf("decode %s: %v", statePath, err)
+	}
+	l1ChainID := st.AppliedIntent.L1ChainID
+	if intentPath != "" {
+		var in deployerIntent
+		if _, err := toml.DecodeFile(intentPath, &in); err != nil {
+			fatalf("decode %s: %v", intentPath, err)
+		}
+		if in.L1ChainID != 0 {
+			l1ChainID = in.L1ChainID
+		}
+	}
+	id, addrs, err := selectDeployment(st, chainID)
+	if err != nil {
+		fatalf("%s: %v", statePath, err)
+	}
+
+	chainPath := filepath.Join(base, "data", "networks", network, chain+".toml")
+	composePath := filepath.Join(base, "data", "networks", network, "compose.toml")
+	chainEdits := []edit{{"", "chain_id", strconv.FormatUint(id, 10)}}
+	if name != "" {
+		chainEdits = append(chainEdits, edit{"", "name", strconv.Quote(name)})
+	}
+	if rpc != "" {
+		chainEdits = append(chainEdits, edit{"", "public_rpc", strconv.Quote(rpc)})
+	}
+	if explorer != "" {
+		chainEdits = append(chainEdits, edit{"", "explorer", strconv.Quote(explorer)})
+	}
+	var networkEdits []edit
+	if l1ChainID != 0 {
+		networkEdits = append(networkEdits, edit{"l1", "chain_id", strconv.FormatUint(l1ChainID, 10)})
+	}
+	names := make([]string, 0, len(addrs))
+	for k := range addrs {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		if k == "DisputeGameFactoryProxy" {
+			networkEdits = append(networkEdits, edit{"publisher", "dispute_game_factory", strconv.Quote(addrs[k])})
+		}
+		chainEdits = append(chainEdits, edit{"addresses", k, strconv.Quote(addrs[k])})
+	}
+
+	var blob []byte
+	var blobPath string
+	if genesisPath != "" {
+		raw, err := os.ReadFile(genesisPath)
+		if err != nil {
+			fatalf("read genesis: %v", err)
+		}
+		ts, gid, err := genesisInfo(raw)
+		if err != nil {
+			fatalf("%s: %v", genesisPath, err)
+		}
+		if gid != id {
+			fatalf("%s: config.chainId=%d, state chain id=%d", genesisPath, gid, id)
+		}
+		ref := reg.GenesisRef(raw)
+		p, err := reg.GenesisBlobPath(ref)
+		if err != nil {
+			fatalf("%v", err)
+		}
+		if blob, err = toolutil.Compress(raw); err != nil {
+			fatalf("compress genesis: %v", err)
+		}
+		blobPath = filepath.Join(base, "data", filepath.FromSlash(p))
+		chainEdits = append(chainEdits,
+			edit{"genesis", "file", strconv.Quote(ref)},
+			edit{"genesis", "l2_time", strconv.FormatUint(ts, 10)},
+		)
+	}
+
+	chainOld, chainNew, chainConflicts := apply(chainPath, chainSkeleton, chainEdits, force)
+	if name == "" && chainOld == "" {
+		// new chain: default the display name to the slug
+		chainNew, _ = tomledit.Set(chainNew, "", "name", strconv.Quote(chain))
+	}
+	netOld, netNew, netConflicts := apply(composePath, networkSkeleton, networkEdits, force)
+	if netOld == "" {
+		netNew = newNetwork(netNew, network, reg.Environment(env))
+	}
+
+	changed := false
+	for _, f := range []struct{ path, old, new string }{
+		{composePath, netOld, netNew},
+		{chainPath, chainOld, chainNew},
+	} {
+		if f.old == f.new {
+			continue
+		}
+		changed = true
+		fmt.Printf("--- %s\n+++ %s\n%s", f.path, f.path, diff(f.old, f.new))
+	}
+	if blobPath != "" {
+		if _, err := os.Stat(blobPath); os.IsNotExist(err) {
+			changed = true
+			fmt.Printf("+++ %s (new genesis blob)\n", blobPath)
+		}
+	}
+
+	conflicts := append(netConflicts, chainConflicts...)
+	dataDir := filepath.Join(base, "data")
+	r, err := reg.NewFromDir(dataDir)
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	if other, err := r.GetChainById(id); err == nil && other.Identifier() != network+"/"+chain {
+		conflicts = append(conflicts, fmt.Sprintf("chain_id %d is already used by %s", id, other.Identifier()))
+	}
+	// The genesis the chain pointed at before, removed below once nothing
+	// references it any more.
+	var prevGenesis string
+	if blobPath != "" && chainOld != "" {
+		c, err := r.GetChainByIdentifier(network + "/" + chain)
+		if err != nil {
+			fatalf("%s: %v", chainPath, err)
+		}
+		if prevGenesis, err = c.GenesisPath(); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	if len(conflicts) > 0 {
+		for _, c := range conflicts {
+			fmt.Fprintf(os.Stderr, "conflict: %s\n", c)
+		}
+		if !force {
+			fatalf("import: %d conflicting value(s); re-run with -force to overwrite", len(conflicts))
+		}
+	}
+	if !changed {
+		fmt.Println("import: registry already up to date")
+		return
+	}
+	if dryRun {
+		return
+	}
+	for _, f := range []struct{ path, old, new string }{
+		{composePath, netOld, netNew},
+		{chainPath, chainOld, chainNew},
+	} {
+		if f.old != f.new {
+			writeFile(f.path, []byte(f.new))
+		}
+	}
+	if blobPath != "" {
+		writeFile(blobPath, blob)
+	}
+	if prevGenesis != "" {
+		removed, err := toolutil.PruneGenesis(dataDir, prevGenesis)
+		if err != nil {
+			fatalf("prune %s: %v", prevGenesis, err)
+		}
+		for _, p := range removed {
+			fmt.Printf("removed %s (no longer referenced)\n", filepath.Join(dataDir, filepath.FromSlash(p)))
+		}
+	}
+	fmt.Println("import: done; run make generate && make check-genesis")
+}
+
+// newNetwork fills the name and environment of a compose.toml created from
+// networkSkeleton.
+func newNetwork(text, slug string, env reg.Environment) string {
+	text, _ = tomledit.Set(text, "", "name", strconv.Quote(slug))
+	text, _ = tomledit.Set(text, "", "environment", strconv.Quote(string(env)))
+	return text
+}
+
+// selectDeployment picks the chain deployment by id (or the only one) and
+// returns its chain id and contract addresses, keyed by superchain-registry
+// style names such as "DisputeGameFactoryProxy".
+func selectDeployment(st deployerState, want uint64) (uint64, map[string]string, error) {
+	if len(st.OpChainDeployments) == 0 {
+		return 0, nil, fmt.Errorf("no opChainDeployments")
+	}
+	var ids []uint64
+	for _, d := range st.OpChainDeployments {
+		var hexID string
+		if err := json.Unmarshal(d["id"], &hexID); err != nil {
+			return 0, nil, fmt.Errorf("opChainDeployments id: %w", err)
+		}
+		n, ok := new(big.Int).SetString(strings.TrimPrefix(hexID, "0x"), 16)
+		if !ok || !n.IsUint64() {
+			return 0, nil, fmt.Errorf("opChainDeployments id %q is not a chain id", hexID)
+		}
+		id := n.Uint64()
+		ids = append(ids, id)
+		if want != 0 && id != want {
+			continue
+		}
+		if want == 0 && len(st.OpChainDeployments) > 1 {
+			continue
+		}
+		addrs := make(map[string]string)
+		for k, v := range d {
+			var s string
+			if json.Unmarshal(v, &s) != nil || !isAddress(s) {
+				continue
+			}
+			addrs[addressName(k)] = s
+		}
+		return id, addrs, nil
+	}
+	if want == 0 {
+		return 0, nil, fmt.Errorf("several chains deployed %v; pick one with -chain-id", ids)
+	}
+	return 0, nil, fmt.Errorf("chain id %d not deployed; have %v", want, ids)
+}
+
+// addressName maps "disputeGameFactoryProxyAddress" and
+// "DisputeGameFactoryProxy" alike to "DisputeGameFactoryProxy".
+func addressName(k string) string {
+	k = strings.TrimSuffix(k, "Address")
+	if k == "" {
+		return k
+	}
+	return strings.ToUpper(k[:1]) + k[1:]
+}
+
+func isAddress(s string) bool {
+	if len(s) != 42 || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, ok := new(big.Int).SetString(s[2:], 16)
+	return ok
+}
+
+// genesisInfo returns the genesis timestamp and config.chainId.
+func genesisInfo(raw []byte) (uint64, uint64, error) {
+	var g struct {
+		Config struct {
+			ChainID json.Number `json:"chainId"`
+		} `json:"config"`
+		Timestamp json.RawMessage `json:"timestamp"`
+	}
+	if err := json.Unmarshal(raw, &g); err != nil {
+		return 0, 0, err
+	}
+	id, err := strconv.ParseUint(g.Config.ChainID.String(), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("config.chainId: %w", err)
+	}
+	s := strings.Trim(string(g.Timestamp), `"`)
+	base := 10
+	if strings.HasPrefix(s, "0x") {
+		s, base = s[2:], 16
+	}
+	ts, err := strconv.ParseUint(s, base, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("timestamp: %w", err)
+	}
+	return ts, id, nil
+}
+
+// apply loads path (or skeleton when it does not exist yet), applies the
+// edits and returns the old text ("" for new files), the new text and any
+// conflicts with non-empty existing values. Conflicting values are only
+// changed when force is set.
+func apply(path, skeleton string, edits []edit, force bool) (string, string, []string) {
+	old := ""
+	if b, err := os.ReadFile(path); err == nil {
+		old = string(b)
+	} else if !os.IsNotExist(err) {
+		fatalf("read %s: %v", path, err)
+	}
+	text, conflicts := applyEdits(path, old, skeleton, edits, force)
+	return old, text, conflicts
+}
+
+// applyEdits is apply on the text old of path.
+func applyEdits(path, old, skeleton string, edits []edit, force bool) (string, []string) {
+	text := old
+	if text == "" {
+		text = skeleton
+	}
+	var conflicts []string
+	for _, e := range edits {
+		cur, ok := tomledit.Get(text, e.table, e.key)
+		if ok && !isEmptyValue(cur) && !sameValue(cur, e.value) {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s = %s, import has %s", path, qualified(e), cur, e.value))
+			if !force {
+				continue
+			}
+		}
+		text, _ = tomledit.Set(text, e.table, e.key, e.value)
+	}
+	return text, conflicts
+}
+
+func qualified(e edit) string {
+	if e.table == "" {
+		return e.key
+	}
+	return e.table + "." + e.key
+}
+
+func isEmptyValue(v string) bool { return v == `""` || v == "0" }
+
+func sameValue(a, b string) bool {
+	ua, errA := strconv.Unquote(a)
+	ub, errB := strconv.Unquote(b)
+	if errA == nil && errB == nil {
+		return strings.EqualFold(ua, ub) // addresses differ only in checksum case
+	}
+	return a == b
+}
+
+// diff renders a line diff of a and b: removed lines with "-", added lines
+// with "+", and unchanged lines with " ".
+func diff(a, b string) string {
+	x := strings.Split(strings.TrimRight(a, "\n"), "\n")
+	y := strings.Split(strings.TrimRight(b, "\n"), "\n")
+	if a == "" {
+		x = nil
+	}
+	// longest common subsequence table
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var buf bytes.Buffer
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			fmt.Fprintf(&buf, " %s\n", x[i])
+			i, j = i+1, j+1
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			fmt.Fprintf(&buf, "-%s\n", x[i])
+			i++
+		default:
+			fmt.Fprintf(&buf, "+%s\n", y[j])
+			j++
+		}
+	}
+	return buf.String()
+}
+
+func writeFile(path string, b []byte) {
+	if err := toolutil.WriteFile(path, b); err != nil {
+		fatalf("%v", err)
+	}
+	fmt.Printf("wrote %s\n", path)
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/compose-network/registry/internal/tomledit"
+	reg "github.com/compose-network/registry/registry"
+)
+
+const editText = `name = "rollup-a" # display only
+chain_id = 0
+
+[addresses]
+Mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+
+[genesis] # filled by genesis-gen
+l2_time = 1756804404
+`
+
+func TestDiff(t *testing.T) {
+	cases := []struct{ name, a, b, want string }{
+		{"new file", "", "a\nb\n", "+a\n+b\n"},
+		{"unchanged", "a\nb\n", "a\nb\n", " a\n b\n"},
+		{"replace", "a\nb\nc\n", "a\nx\nc\n", " a\n-b\n+x\n c\n"},
+		{"insert", "a\nc\n", "a\nb\nc\n", " a\n+b\n c\n"},
+		{"delete", "a\nb\nc\n", "a\nc\n", " a\n-b\n c\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := diff(tc.a, tc.b); got != tc.want {
+				t.Fatalf("diff() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplyEdits_Conflicts(t *testing.T) {
+	edits := []edit{
+		{"", "chain_id", "77777"}, // empty before: no conflict
+		{"addresses", "Mailbox", `"0x248721A59A2756E579026ADA017BD9B6ADFE3E57"`}, // case only: no conflict
+		{"genesis", "l2_time", "1756804500"},                                     // conflict
+		{"sequencer", "host", `"op-geth"`},                                       // missing table
+	}
+	cases := []struct {
+		name  string
+		force bool
+		time  string
+	}{
+		{"kept without force", false, "1756804404"},
+		{"overwritten with force", true, "1756804500"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, conflicts := applyEdits("rollup-a.toml", editText, chainSkeleton, edits, tc.force)
+			want := []string{"rollup-a.toml: genesis.l2_time = 1756804404, import has 1756804500"}
+			if strings.Join(conflicts, "\n") != strings.Join(want, "\n") {
+				t.Fatalf("conflicts = %q, want %q", conflicts, want)
+			}
+			for _, c := range []struct{ table, key, want string }{
+				{"", "chain_id", "77777"},
+				{"addresses", "Mailbox", `"0x248721A59A2756E579026ADA017BD9B6ADFE3E57"`},
+				{"genesis", "l2_time", tc.time},
+				{"sequencer", "host", `"op-geth"`},
+			} {
+				if v, _ := tomledit.Get(text, c.table, c.key); v != c.want {
+					t.Errorf("%s.%s = %s, want %s", c.table, c.key, v, c.want)
+				}
+			}
+		})
+	}
+
+	text, conflicts := applyEdits("new.toml", "", chainSkeleton, edits[:1], false)
+	if len(conflicts) != 0 || !strings.HasPrefix(text, "name = \"\"\n") {
+		t.Fatalf("new file: conflicts %q, text\n%s\nwant the skeleton with no conflicts", conflicts, text)
+	}
+}
+
+func TestNewNetwork(t *testing.T) {
+	netText, _ := applyEdits("compose.toml", "", networkSkeleton, []edit{{"l1", "chain_id", "560048"}}, false)
+	chainText, _ := applyEdits("rollup-c.toml", "", chainSkeleton, []edit{{"", "chain_id", "77777"}}, false)
+	fsys := fstest.MapFS{
+		"networks/staging/compose.toml":  {Data: []byte(newNetwork(netText, "staging", reg.EnvTestnet))},
+		"networks/staging/rollup-c.toml": {Data: []byte(chainText)},
+	}
+	r, err := reg.NewFromFS(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate() on a new network: %v", err)
+	}
+	n, err := r.GetNetworkBySlug("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env, err := n.Environment(); err != nil || env != reg.EnvTestnet {
+		t.Fatalf("Environment() = %q, %v, want %q", env, err, reg.EnvTestnet)
+	}
+}
+
+func TestSelectDeployment(t *testing.T) {
+	deployment := func(id string, addrs map[string]string) map[string]json.RawMessage {
+		d := map[string]json.RawMessage{"id": json.RawMessage(`"` + id + `"`)}
+		for k, v := range addrs {
+			d[k] = json.RawMessage(`"` + v + `"`)
+		}
+		return d
+	}
+	const factory = "0x54e692F4e290409035a9cC6A3d55eB047c82112C"
+	a := deployment("0x0000000000000000000000000000000000000000000000000000000000012fd1", map[string]string{
+		"disputeGameFactoryProxyAddress": factory,
+		"salt":                           "not an address",
+	})
+	b := deployment("0x15b38", map[string]string{"DisputeGameFactoryProxy": factory})
+	cases := []struct {
+		name   string
+		deps   []map[string]json.RawMessage
+		want   uint64
+		wantID uint64
+		addrs  map[string]string
+		err    string
+	}{
+		{"only deployment", []map[string]json.RawMessage{a}, 0, 77777, map[string]string{"DisputeGameFactoryProxy": factory}, ""},
+		{"by id", []map[string]json.RawMessage{a, b}, 88888, 88888, map[string]string{"DisputeGameFactoryProxy": factory}, ""},
+		{"several without id", []map[string]json.RawMessage{a, b}, 0, 0, nil, "several chains deployed [77777 88888]"},
+		{"unknown id", []map[string]json.RawMessage{a, b}, 99999, 0, nil, "chain id 99999 not deployed"},
+		{"none", nil, 0, 0, nil, "no opChainDeployments"},
+		{"bad id", []map[string]json.RawMessage{deployment("rollup", nil)}, 0, 0, nil, "is not a chain id"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var st deployerState
+			st.OpChainDeployments = tc.deps
+			id, addrs, err := selectDeployment(st, tc.want)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("selectDeployment() error = %v, want %q", err, tc.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectDeployment() error: %v", err)
+			}
+			if id != tc.wantID || !maps.Equal(addrs, tc.addrs) {
+				t.Fatalf("selectDeployment() = %d, %v, want %d, %v", id, addrs, tc.wantID, tc.addrs)
+			}
+		})
+	}
+}
+
+func TestAddressName(t *testing.T) {
+	for in, want := range map[string]string{
+		"disputeGameFactoryProxyAddress": "DisputeGameFactoryProxy",
+		"DisputeGameFactoryProxy":        "DisputeGameFactoryProxy",
+		"systemConfigProxyAddress":       "SystemConfigProxy",
+		"Address":                        "",
+		"mailbox":                        "Mailbox",
+	} {
+		if got := addressName(in); got != want {
+			t.Errorf("addressName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
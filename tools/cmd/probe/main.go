@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+// result is one probed endpoint: a chain or a network L1.
+type result struct {
+	Target        string `json:"target"` // <network>/<chain> or <network>/l1
+	RPC           string `json:"rpc"`
+	WantChainID   uint64 `json:"want_chain_id"`
+	GotChainID    uint64 `json:"got_chain_id,omitempty"`
+	LatencyMS     int64  `json:"latency_ms"`
+	RPCError      string `json:"rpc_error,omitempty"`
+	Explorer      string `json:"explorer,omitempty"`
+	ExplorerCode  int    `json:"explorer_status,omitempty"`
+	ExplorerError string `json:"explorer_error,omitempty"`
+	OK            bool   `json:"ok"`
+}
+
+type prober struct {
+	client *http.Client
+}
+
+func main() {
+	var base, format, only string
+	var timeout time.Duration
+	var skipExplorer bool
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&format, "format", "text", "report format: text, json")
+	flag.StringVar(&only, "network", "", "probe a single network (default all)")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.BoolVar(&skipExplorer, "skip-explorer", false, "do not check explorer URLs")
+	flag.Parse()
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	p := prober{client: &http.Client{Timeout: timeout}}
+	results, err := p.run(context.Background(), r, only, !skipExplorer)
+	if err != nil {
+		fatalf("probe: %v", err)
+	}
+	if err := report(os.Stdout, format, results); err != nil {
+		fatalf("report: %v", err)
+	}
+	for _, res := range results {
+		if !res.OK {
+			os.Exit(1)
+		}
+	}
+}
+
+// targets lists the endpoints to probe: each network's L1, then its
+// chains. Retired networks and chains are skipped.
+func targets(r reg.Registry, only string) ([]result, error) {
+	nets, err := r.ListNetworks(reg.ExcludeRetired())
+	if err != nil {
+		return nil, err
+	}
+	var out []result
+	for _, n := range nets {
+		if only != "" && n.Slug() != only {
+			continue
+		}
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("load network %s: %w", n.Slug(), err)
+		}
+		out = append(out, result{
+			Target:      n.Slug() + "/l1",
+			RPC:         ncfg.L1.PublicRPC,
+			WantChainID: ncfg.L1.ChainID,
+			Explorer:    ncfg.L1.Explorer,
+		})
+		chains, err := n.ListChains(reg.ExcludeRetired())
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chains {
+			cfg, err := c.LoadConfig()
+			if err != nil {
+				return nil, fmt.Errorf("load %s: %w", c.Identifier(), err)
+			}
+			out = append(out, result{
+				Target:      c.Identifier(),
+				RPC:         cfg.PublicRPC,
+				WantChainID: cfg.ChainID,
+				Explorer:    cfg.Explorer,
+			})
+		}
+	}
+	if only != "" && len(out) == 0 {
+		return nil, fmt.Errorf("%w: %s", reg.ErrNetworkNotFound, only)
+	}
+	return out, nil
+}
+
+// run probes every target concurrently; results keep target order.
+func (p prober) run(ctx context.Context, r reg.Registry, only string, explorer bool) ([]result, error) {
+	results, err := targets(r, only)
+	if err != nil {
+		return nil, err
+	}
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(res *result) {
+			defer wg.Done()
+			p.probe(ctx, res, explorer)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (p prober) probe(ctx context.Context, res *result, explorer bool) {
+	start := time.Now()
+	id, err := p.chainID(ctx, res.RPC)
+	res.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		res.RPCError = err.Error()
+	} else {
+		res.GotChainID = id
+		if id != res.WantChainID {
+			res.RPCError = fmt.Sprintf("chain id mismatch: rpc reports %d, registry has %d", id, res.WantChainID)
+		}
+	}
+	if explorer && res.Explorer != "" {
+		res.ExplorerCode, err = p.status(ctx, res.Explorer)
+		if err != nil {
+			res.ExplorerError = err.Error()
+		} else if res.ExplorerCode >= 400 {
+			res.ExplorerError = "HTTP " + strconv.Itoa(res.ExplorerCode)
+		}
+	}
+	res.OK = res.RPCError == "" && res.ExplorerError == ""
+}
+
+// chainID calls eth_chainId on url.
+func (p prober) chainID(ctx context.Context, url string) (uint64, error) {
+	if url == "" {
+		return 0, fmt.Errorf("no public_rpc")
+	}
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	var out struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return 0, fmt.Errorf("decode response: %w", err)
+	}
+	if out.Error != nil {
+		return 0, fmt.Errorf("rpc error: %s", out.Error.Message)
+	}
+	id, err := strconv.ParseUint(strings.TrimPrefix(out.Result, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid eth_chainId result %q", out.Result)
+	}
+	return id, nil
+}
+
+// status returns the HTTP status of a GET to url, following redirects.
+func (p prober) status(ctx context.Context, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func report(w io.Writer, format string, results []result) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case "text":
+		failed := 0
+		for _, res := range results {
+			status := "ok"
+			if !res.OK {
+				status = "FAIL"
+				failed++
+			}
+			fmt.Fprintf(w, "%-4s %-24s chain_id=%d %dms %s\n", status, res.Target, res.WantChainID, res.LatencyMS, res.RPC)
+			if res.RPCError != "" {
+				fmt.Fprintf(w, "     rpc: %s\n", res.RPCError)
+			}
+			if res.ExplorerError != "" {
+				fmt.Fprintf(w, "     explorer %s: %s\n", res.Explorer, res.ExplorerError)
+			}
+		}
+		fmt.Fprintf(w, "%d endpoint(s), %d failing\n", len(results), failed)
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
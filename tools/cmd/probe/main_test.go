@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+// rpcServer answers eth_chainId with id.
+func rpcServer(t *testing.T, id uint64) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "eth_chainId" {
+			http.Error(w, "unexpected method", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%x"}`, req.ID, id)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func statusServer(t *testing.T, code int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestProbe(t *testing.T) {
+	l1 := rpcServer(t, 560048)
+	good := rpcServer(t, 77777)
+	stale := rpcServer(t, 1) // answers for another chain
+	explorerOK := statusServer(t, http.StatusOK)
+	explorerDown := statusServer(t, http.StatusBadGateway)
+
+	files := fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte(fmt.Sprintf(`name = "testnet"
+[l1]
+chain_id = 560048
+public_rpc = %q
+explorer = %q
+`, l1.URL, explorerOK.URL))},
+		"networks/testnet/good.toml":       {Data: []byte(fmt.Sprintf("chain_id = 77777\npublic_rpc = %q\nexplorer = %q\n", good.URL, explorerOK.URL))},
+		"networks/testnet/stale.toml":      {Data: []byte(fmt.Sprintf("chain_id = 88888\npublic_rpc = %q\nexplorer = %q\n", stale.URL, explorerOK.URL))},
+		"networks/testnet/noexplorer.toml": {Data: []byte(fmt.Sprintf("chain_id = 77777\npublic_rpc = %q\nexplorer = %q\n", good.URL, explorerDown.URL))},
+		"networks/testnet/retired.toml":    {Data: []byte("chain_id = 99999\npublic_rpc = \"http://127.0.0.1:1\"\n[lifecycle]\nstatus = \"retired\"\ndeprecated_on = 2026-01-01\n")},
+	}
+	r, err := reg.NewFromFS(files)
+	if err != nil {
+		t.Fatalf("NewFromFS() error: %v", err)
+	}
+
+	p := prober{client: &http.Client{Timeout: 5 * time.Second}}
+	results, err := p.run(context.Background(), r, "", true)
+	if err != nil {
+		t.Fatalf("run() error: %v", err)
+	}
+	got := make(map[string]result)
+	for _, res := range results {
+		got[res.Target] = res
+	}
+	if res := got["testnet/l1"]; !res.OK || res.GotChainID != 560048 {
+		t.Fatalf("l1 = %+v, want ok", res)
+	}
+	if res := got["testnet/good"]; !res.OK || res.ExplorerCode != http.StatusOK {
+		t.Fatalf("good = %+v, want ok", res)
+	}
+	if res := got["testnet/stale"]; res.OK || !strings.Contains(res.RPCError, "mismatch") || res.GotChainID != 1 {
+		t.Fatalf("stale = %+v, want chain id mismatch", res)
+	}
+	if res := got["testnet/noexplorer"]; res.OK || res.RPCError != "" || res.ExplorerCode != http.StatusBadGateway {
+		t.Fatalf("noexplorer = %+v, want explorer failure only", res)
+	}
+
+	var text bytes.Buffer
+	if err := report(&text, "text", results); err != nil {
+		t.Fatalf("report(text) error: %v", err)
+	}
+	if !strings.Contains(text.String(), "4 endpoint(s), 2 failing") {
+		t.Fatalf("text report missing summary:\n%s", text.String())
+	}
+	var js bytes.Buffer
+	if err := report(&js, "json", results); err != nil {
+		t.Fatalf("report(json) error: %v", err)
+	}
+	var decoded []result
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil || len(decoded) != len(results) {
+		t.Fatalf("json report = %s (%v)", js.String(), err)
+	}
+}
+
+func TestProbe_Unreachable(t *testing.T) {
+	dead := httptest.NewServer(http.NotFoundHandler())
+	url := dead.URL
+	dead.Close()
+
+	p := prober{client: &http.Client{Timeout: time.Second}}
+	res := result{Target: "testnet/dead", RPC: url, WantChainID: 1}
+	p.probe(context.Background(), &res, false)
+	if res.OK || res.RPCError == "" {
+		t.Fatalf("dead endpoint = %+v, want rpc error", res)
+	}
+}
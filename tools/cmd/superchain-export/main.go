@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	reg "github.com/compose-network/registry/registry"
+	"github.com/compose-network/registry/tools/internal/toolutil"
+)
+
+// superchainCfg mirrors superchain/configs/<superchain>/superchain.toml.
+type superchainCfg struct {
+	Name string `toml:"name"`
+	L1   struct {
+		ChainID   uint64 `toml:"chain_id"`
+		PublicRPC string `toml:"public_rpc"`
+		Explorer  string `toml:"explorer"`
+	} `toml:"l1"`
+}
+
+// chainOut mirrors superchain/configs/<superchain>/<chain>.toml. Fields the
+// registry does not track (batch inbox, L1/L2 genesis hashes, system config)
+// are left out rather than guessed.
+type chainOut struct {
+	Name                 string            `toml:"name"`
+	PublicRPC            string            `toml:"public_rpc"`
+	Explorer             string            `toml:"explorer"`
+	SuperchainLevel      int               `toml:"superchain_level"`
+	GovernedByOptimism   bool              `toml:"governed_by_optimism"`
+	DataAvailabilityType string            `toml:"data_availability_type"`
+	ChainID              uint64            `toml:"chain_id"`
+	GasPayingToken       string            `toml:"gas_paying_token,omitempty"`
+	Hardforks            map[string]uint64 `toml:"hardforks,omitempty"`
+	Optimism             map[string]uint64 `toml:"optimism,omitempty"`
+	Genesis              struct {
+		L2Time uint64 `toml:"l2_time"`
+		L2     struct {
+			Number uint64 `toml:"number"`
+		} `toml:"l2"`
+	} `toml:"genesis"`
+	Roles     map[string]string `toml:"roles,omitempty"`
+	Addresses map[string]string `toml:"addresses,omitempty"`
+}
+
+// chainExtra reads the whole [addresses] table, which ChainConfig decodes
+// only the well-known entries of.
+type chainExtra struct {
+	Addresses map[string]string `toml:"addresses"`
+}
+
+// OP Stack forks recorded under [hardforks], keyed by genesis config field.
+var opForks = []string{
+	"canyonTime", "deltaTime", "ecotoneTime", "fjordTime",
+	"graniteTime", "holoceneTime", "isthmusTime", "jovianTime",
+}
+
+func main() {
+	var base, out, only string
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&out, "out", "build/superchain-registry", "output directory (superchain-registry checkout layout)")
+	flag.StringVar(&only, "network", "", "export a single network (default all)")
+	flag.Parse()
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	files, chains, err := export(r, only)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	root := filepath.Join(base, out)
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := toolutil.WriteFile(filepath.Join(root, filepath.FromSlash(name)), files[name]); err != nil {
+			fatalf("%v", err)
+		}
+	}
+	fmt.Printf("wrote %s (chains=%d)\n", root, chains)
+}
+
+// export renders the superchain-registry files of every network, or of the
+// network named only when it is set, keyed by slash-separated path relative
+// to the checkout root. It also returns the number of chains exported.
+func export(r reg.Registry, only string) (map[string][]byte, int, error) {
+	networks, err := r.ListNetworks()
+	if err != nil {
+		return nil, 0, fmt.Errorf("list networks: %w", err)
+	}
+	files := make(map[string][]byte)
+	written := 0
+	for _, n := range networks {
+		if only != "" && n.Slug() != only {
+			continue
+		}
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			return nil, 0, fmt.Errorf("load network %s: %w", n.Slug(), err)
+		}
+		cfgDir := path.Join("superchain", "configs", n.Slug())
+		var sc superchainCfg
+		sc.Name = toolutil.OrDefault(ncfg.Name, n.Slug())
+		sc.L1.ChainID = ncfg.L1.ChainID
+		sc.L1.PublicRPC = ncfg.L1.PublicRPC
+		sc.L1.Explorer = ncfg.L1.Explorer
+		b, err := encodeTOML(sc)
+		if err != nil {
+			return nil, 0, fmt.Errorf("%s: %w", n.Slug(), err)
+		}
+		files[path.Join(cfgDir, "superchain.toml")] = b
+
+		chains, err := n.ListChains()
+		if err != nil {
+			return nil, 0, fmt.Errorf("list chains for %s: %w", n.Slug(), err)
+		}
+		for _, c := range chains {
+			co, genesis, err := exportChain(c, ncfg)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%s: %w", c.Identifier(), err)
+			}
+			b, err := encodeTOML(co)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%s: %w", c.Identifier(), err)
+			}
+			files[path.Join(cfgDir, c.Slug()+".toml")] = b
+			if genesis != nil {
+				files[path.Join("superchain", "extra", "genesis", n.Slug(), c.Slug()+".json.zst")] = genesis
+			}
+			written++
+		}
+	}
+	if only != "" && written == 0 {
+		return nil, 0, fmt.Errorf("no chains exported for network %q", only)
+	}
+	return files, written, nil
+}
+
+// exportChain builds the superchain-registry view of one chain and returns
+// its genesis recompressed with zstd (nil when no genesis is available).
+func exportChain(c reg.Chain, ncfg reg.NetworkConfig) (chainOut, []byte, error) {
+	var co chainOut
+	cfg, err := c.LoadConfig()
+	if err != nil {
+		return co, nil, err
+	}
+	var extra chainExtra
+	if err := c.Decode(&extra); err != nil {
+		return co, nil, err
+	}
+
+	co.Name = cfg.Name
+	co.PublicRPC = cfg.PublicRPC
+	co.Explorer = cfg.Explorer
+	co.DataAvailabilityType = toolutil.OrDefault(cfg.DataAvailabilityType, "eth-da")
+	co.ChainID = cfg.ChainID
+	co.GasPayingToken = string(cfg.NativeCurrency.L1Token)
+	co.Genesis.L2Time = cfg.Genesis.L2Time
+	if len(cfg.Roles) > 0 {
+		co.Roles = make(map[string]string, len(cfg.Roles))
+		for k, v := range cfg.Roles {
+			co.Roles[k] = string(v)
+		}
+	}
+	co.Addresses = make(map[string]string)
+	for k, v := range extra.Addresses {
+		co.Addresses[k] = v
+	}
+	if a := ncfg.Publisher.DisputeGameFactory; a.IsReal() {
+		co.Addresses["DisputeGameFactoryProxy"] = string(a)
+	}
+
+	genesis, err := c.LoadGenesis()
+	if errors.Is(err, reg.ErrGenesisNotFound) {
+		return co, nil, nil
+	}
+	if err != nil {
+		return co, nil, err
+	}
+	var g struct {
+		Config map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(genesis, &g); err != nil {
+		return co, nil, fmt.Errorf("decode genesis: %w", err)
+	}
+	for _, f := range opForks {
+		if v, ok, err := configUint(g.Config, f); err != nil {
+			return co, nil, err
+		} else if ok {
+			if co.Hardforks == nil {
+				co.Hardforks = make(map[string]uint64)
+			}
+			co.Hardforks[snake(f)] = v
+		}
+	}
+	if op, ok := g.Config["optimism"]; ok {
+		var params map[string]json.RawMessage
+		if err := json.Unmarshal(op, &params); err != nil {
+			return co, nil, fmt.Errorf("decode config.optimism: %w", err)
+		}
+		for _, k := range []string{"eip1559Elasticity", "eip1559Denominator", "eip1559DenominatorCanyon"} {
+			if v, ok, err := configUint(params, k); err != nil {
+				return co, nil, err
+			} else if ok {
+				if co.Optimism == nil {
+					co.Optimism = make(map[string]uint64)
+				}
+				co.Optimism[snake(k)] = v
+			}
+		}
+	}
+
+	blob, err := toolutil.Compress(genesis)
+	return co, blob, err
+}
+
+func configUint(m map[string]json.RawMessage, key string) (uint64, bool, error) {
+	v, ok := m[key]
+	if !ok || string(v) == "null" {
+		return 0, false, nil
+	}
+	s := strings.Trim(string(v), `"`)
+	base := 10
+	if strings.HasPrefix(s, "0x") {
+		s, base = s[2:], 16
+	}
+	u, err := strconv.ParseUint(s, base, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("config %s: %w", key, err)
+	}
+	return u, true, nil
+}
+
+// snake converts a genesis config key ("canyonTime", "eip1559Denominator")
+// to the superchain-registry spelling ("canyon_time", "eip1559_denominator").
+func snake(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+func encodeTOML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
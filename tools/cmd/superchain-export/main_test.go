@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	reg "github.com/compose-network/registry/registry"
+	"github.com/klauspost/compress/zstd"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.golden")
+
+const exportGenesis = `{
+  "config": {
+    "chainId": 90001,
+    "canyonTime": 0,
+    "ecotoneTime": "0x0",
+    "holoceneTime": 1756804404,
+    "optimism": {"eip1559Elasticity": 6, "eip1559Denominator": 50, "eip1559DenominatorCanyon": 250}
+  },
+  "timestamp": "0x68b6d434"
+}`
+
+func exportFS() fstest.MapFS {
+	return fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte(`name = "Testnet"
+environment = "testnet"
+
+[l1]
+chain_id = 11155111
+public_rpc = "https://l1.example.org"
+explorer = "https://l1-explorer.example.org"
+
+[publisher]
+dispute_game_factory = "0x54e692F4e290409035a9cC6A3d55eB047c82112C"
+`)},
+		"networks/testnet/rollup-a.toml": {Data: []byte(`name = "Rollup A"
+public_rpc = "https://rpc-a.example.org"
+explorer = "https://explorer-a.example.org"
+chain_id = 90001
+data_availability_type = "alt-da"
+
+[native_currency]
+name = "Compose"
+symbol = "CMP"
+decimals = 18
+l1_token = "0x2498eF6bc1476652F5a47C50FAffBEa39Abbc4e5"
+
+[addresses]
+Mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+
+[roles]
+SystemConfigOwner = "0x1111111111111111111111111111111111111111"
+
+[genesis]
+l2_time = 1756804404
+`)},
+		"networks/testnet/rollup-b.toml": {Data: []byte(`name = "rollup-b"
+chain_id = 90002
+
+[native_currency]
+name = "Ether"
+symbol = "ETH"
+decimals = 18
+`)},
+		// Plain JSON is accepted under the .json.zst name.
+		"genesis/testnet/rollup-a.json.zst": {Data: []byte(exportGenesis)},
+	}
+}
+
+func TestExport_Golden(t *testing.T) {
+	r, err := reg.NewFromFS(exportFS())
+	if err != nil {
+		t.Fatalf("NewFromFS() error: %v", err)
+	}
+	files, chains, err := export(r, "testnet")
+	if err != nil {
+		t.Fatalf("export() error: %v", err)
+	}
+	if chains != 2 {
+		t.Fatalf("export() chains = %d, want 2", chains)
+	}
+	const genesisPath = "superchain/extra/genesis/testnet/rollup-a.json.zst"
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{
+		"superchain/configs/testnet/rollup-a.toml",
+		"superchain/configs/testnet/rollup-b.toml",
+		"superchain/configs/testnet/superchain.toml",
+		genesisPath,
+	}
+	if !slices.Equal(names, want) {
+		t.Fatalf("export() files = %v, want %v", names, want)
+	}
+
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Close()
+	if raw, err := dec.DecodeAll(files[genesisPath], nil); err != nil || string(raw) != exportGenesis {
+		t.Fatalf("%s does not decompress to the registry genesis (err %v)", genesisPath, err)
+	}
+
+	for _, name := range want[:3] {
+		t.Run(path.Base(name), func(t *testing.T) {
+			golden := filepath.Join("testdata", strings.TrimSuffix(path.Base(name), ".toml")+".golden")
+			if *update {
+				if err := os.WriteFile(golden, files[name], 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden (run go test -update): %v", err)
+			}
+			if !bytes.Equal(files[name], want) {
+				t.Fatalf("%s mismatch (run go test -update)\n--- got\n%s\n--- want\n%s", name, files[name], want)
+			}
+		})
+	}
+}
+
+func TestExport_UnknownNetwork(t *testing.T) {
+	r, err := reg.NewFromFS(exportFS())
+	if err != nil {
+		t.Fatalf("NewFromFS() error: %v", err)
+	}
+	if _, _, err := export(r, "mainnet"); err == nil || !strings.Contains(err.Error(), `no chains exported for network "mainnet"`) {
+		t.Fatalf("export(mainnet) error = %v, want no chains exported", err)
+	}
+}
@@ -11,13 +11,15 @@ import (
 
 	"github.com/BurntSushi/toml"
 	t "github.com/compose-network/registry/internal/types"
+	reg "github.com/compose-network/registry/registry"
 )
 
 var identRe = regexp.MustCompile(`^[a-z0-9-]+/[a-z0-9-]+$`)
 
 func main() {
-	var in string
+	var in, data string
 	flag.StringVar(&in, "in", "data/chainList.toml", "input TOML path")
+	flag.StringVar(&data, "data", "data", "registry data dir whose network configs are checked (empty to skip)")
 	flag.Parse()
 	var cl t.ChainListTOML
 	if _, err := toml.DecodeFile(in, &cl); err != nil {
@@ -26,6 +28,22 @@ func main() {
 	if err := validate(cl); err != nil {
 		fatalf("validation failed: %v", err)
 	}
+	if data != "" {
+		r, err := reg.NewFromDir(data)
+		if err != nil {
+			fatalf("open registry: %v", err)
+		}
+		if err := r.Validate(); err != nil {
+			fatalf("validation failed: %v", err)
+		}
+		known, err := knownPlaceholders(r)
+		if err != nil {
+			fatalf("validation failed: %v", err)
+		}
+		for _, p := range known {
+			fmt.Printf("note: %s is a known placeholder\n", p)
+		}
+	}
 	fmt.Println("validation ok")
 }
 
@@ -73,6 +91,22 @@ func validate(cl t.ChainListTOML) error {
 				return fmt.Errorf("chain[%d] explorers: %w", i, err)
 			}
 		}
+		// Native currency required; gas_paying_token only for custom gas token chains
+		if c.NativeCurrency == nil {
+			return fmt.Errorf("chain[%d]: native_currency required", i)
+		}
+		nc := reg.NativeCurrency{
+			Name:     c.NativeCurrency.Name,
+			Symbol:   c.NativeCurrency.Symbol,
+			Decimals: c.NativeCurrency.Decimals,
+			L1Token:  reg.Address(c.GasPayingToken),
+		}
+		if err := nc.Validate(); err != nil {
+			return fmt.Errorf("chain[%d]: %w", i, err)
+		}
+		if nc.IsCustomGasToken() && strings.EqualFold(nc.Symbol, "ETH") {
+			return fmt.Errorf("chain[%d]: custom gas token must not use symbol ETH", i)
+		}
 		// dataAvailabilityType one of known values (align with OP: eth-da or alt-da)
 		switch strings.ToLower(c.DataAvailabilityType) {
 		case "eth-da", "alt-da":
@@ -83,6 +117,40 @@ func validate(cl t.ChainListTOML) error {
 	return nil
 }
 
+// knownPlaceholders lists the address fields configs mark under
+// placeholders as "<network>: <field>" or "<network>/<chain>: <field>".
+// Validate has checked that each holds a zero or placeholder address.
+func knownPlaceholders(r reg.Registry) ([]string, error) {
+	nets, err := r.ListNetworks()
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	for _, n := range nets {
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range ncfg.Placeholders {
+			out = append(out, n.Slug()+": "+f)
+		}
+		chains, err := n.ListChains()
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chains {
+			cfg, err := c.LoadConfig()
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range cfg.Placeholders {
+				out = append(out, c.Identifier()+": "+f)
+			}
+		}
+	}
+	return out, nil
+}
+
 func mustURL(s string) error {
 	if s == "" {
 		return errors.New("empty URL")
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+func TestKnownPlaceholders(t *testing.T) {
+	r, err := reg.NewFromFS(fstest.MapFS{
+		"networks/hoodi/compose.toml":  {Data: []byte(`placeholders = ["publisher.superblock_contract"]`)},
+		"networks/hoodi/rollup-a.toml": {Data: []byte(`placeholders = ["addresses.Bridge"]`)},
+		"networks/hoodi/rollup-b.toml": {Data: []byte(``)},
+	})
+	if err != nil {
+		t.Fatalf("NewFromFS error: %v", err)
+	}
+	got, err := knownPlaceholders(r)
+	if err != nil {
+		t.Fatalf("knownPlaceholders error: %v", err)
+	}
+	want := []string{"hoodi: publisher.superblock_contract", "hoodi/rollup-a: addresses.Bridge"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("knownPlaceholders = %q, want %q", got, want)
+	}
+}
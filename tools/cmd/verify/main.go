@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	reg "github.com/compose-network/registry/registry"
+	"golang.org/x/crypto/sha3"
+)
+
+// Check outcomes. Only statusOK and statusUnset pass.
+const (
+	statusOK          = "ok"
+	statusUnset       = "unset"       // address left empty in the registry
+	statusPlaceholder = "placeholder" // zero or a low dummy address like 0x…0001
+	statusNoCode      = "no-code"
+	statusMismatch    = "hash-mismatch"
+	statusRPCError    = "rpc-error"
+	statusInvalid     = "invalid"
+)
+
+// check is one registered address: a chain [addresses] entry (verified on
+// the chain's RPC) or a network [publisher] contract (verified on L1).
+type check struct {
+	Target   string `json:"target"` // <network>/<chain> or <network>/l1
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	RPC      string `json:"rpc"`
+	Status   string `json:"status"`
+	CodeSize int    `json:"code_size,omitempty"`
+	CodeHash string `json:"code_hash,omitempty"`
+	Want     string `json:"want_code_hash,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// pins maps contract names to expected keccak256 runtime code hashes. Top
+// level keys apply everywhere; a table named after a target overrides them.
+type pins map[string]any
+
+func (p pins) lookup(target, name string) string {
+	if t, ok := p[target].(map[string]any); ok {
+		if s, ok := t[name].(string); ok {
+			return strings.ToLower(s)
+		}
+	}
+	if s, ok := p[name].(string); ok {
+		return strings.ToLower(s)
+	}
+	return ""
+}
+
+func main() {
+	var base, only, pinsPath, format string
+	var timeout time.Duration
+	flag.StringVar(&base, "base", ".", "repository root (registry module)")
+	flag.StringVar(&only, "network", "", "verify a single network (default all)")
+	flag.StringVar(&pinsPath, "pins", "", "TOML file of expected runtime code hashes (optional)")
+	flag.StringVar(&format, "format", "text", "report format: text, json")
+	flag.DurationVar(&timeout, "timeout", 10*time.Second, "per-request timeout")
+	flag.Parse()
+
+	r, err := reg.NewFromDir(filepath.Join(base, "data"))
+	if err != nil {
+		fatalf("open registry: %v", err)
+	}
+	var p pins
+	if pinsPath != "" {
+		if _, err := toml.DecodeFile(pinsPath, &p); err != nil {
+			fatalf("decode %s: %v", pinsPath, err)
+		}
+	}
+	checks, err := collect(r, only)
+	if err != nil {
+		fatalf("verify: %v", err)
+	}
+	client := &http.Client{Timeout: timeout}
+	for i := range checks {
+		verify(context.Background(), client, &checks[i], p)
+	}
+	if err := report(os.Stdout, format, checks); err != nil {
+		fatalf("report: %v", err)
+	}
+	for _, c := range checks {
+		if c.Status != statusOK && c.Status != statusUnset {
+			os.Exit(1)
+		}
+	}
+}
+
+// collect lists every registered address in network order: the network's
+// publisher contracts on L1, then each chain's [addresses] by name.
+// Retired networks and chains are skipped.
+func collect(r reg.Registry, only string) ([]check, error) {
+	nets, err := r.ListNetworks(reg.ExcludeRetired())
+	if err != nil {
+		return nil, err
+	}
+	var out []check
+	for _, n := range nets {
+		if only != "" && n.Slug() != only {
+			continue
+		}
+		ncfg, err := n.LoadConfig()
+		if err != nil {
+			return nil, fmt.Errorf("load network %s: %w", n.Slug(), err)
+		}
+		l1 := n.Slug() + "/l1"
+		out = append(out,
+			check{Target: l1, Name: "superblock_contract", Address: string(ncfg.Publisher.SuperblockContract), RPC: ncfg.L1.PublicRPC},
+			check{Target: l1, Name: "dispute_game_factory", Address: string(ncfg.Publisher.DisputeGameFactory), RPC: ncfg.L1.PublicRPC},
+		)
+		chains, err := n.ListChains(reg.ExcludeRetired())
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range chains {
+			cfg, err := c.LoadConfig()
+			if err != nil {
+				return nil, fmt.Errorf("load %s: %w", c.Identifier(), err)
+			}
+			addrs, err := chainAddresses(c)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(addrs))
+			for name := range addrs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				out = append(out, check{Target: c.Identifier(), Name: name, Address: addrs[name], RPC: cfg.PublicRPC})
+			}
+		}
+	}
+	if only != "" && len(out) == 0 {
+		return nil, fmt.Errorf("%w: %s", reg.ErrNetworkNotFound, only)
+	}
+	return out, nil
+}
+
+// chainAddresses reads the whole [addresses] table; ChainConfig only
+// decodes the well-known entries.
+func chainAddresses(c reg.Chain) (map[string]string, error) {
+	var v struct {
+		Addresses map[string]string `toml:"addresses"`
+	}
+	if err := c.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v.Addresses, nil
+}
+
+func verify(ctx context.Context, client *http.Client, c *check, p pins) {
+	switch reg.Address(c.Address).Kind() {
+	case reg.AddressUnset:
+		c.Status = statusUnset
+		return
+	case reg.AddressInvalid:
+		c.Status, c.Error = statusInvalid, "not a 0x-prefixed 20-byte hex address"
+		return
+	case reg.AddressZero, reg.AddressPlaceholder:
+		c.Status = statusPlaceholder
+		return
+	}
+	code, err := getCode(ctx, client, c.RPC, c.Address)
+	if err != nil {
+		c.Status, c.Error = statusRPCError, err.Error()
+		return
+	}
+	c.CodeSize = len(code)
+	if len(code) == 0 {
+		c.Status = statusNoCode
+		return
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write(code)
+	c.CodeHash = "0x" + hex.EncodeToString(h.Sum(nil))
+	c.Want = p.lookup(c.Target, c.Name)
+	if c.Want != "" && c.Want != c.CodeHash {
+		c.Status = statusMismatch
+		return
+	}
+	c.Status = statusOK
+}
+
+// getCode calls eth_getCode(addr, "latest") on url.
+func getCode(ctx context.Context, client *http.Client, url, addr string) ([]byte, error) {
+	if url == "" {
+		return nil, fmt.Errorf("no public_rpc")
+	}
+	body, _ := json.Marshal(map[string]any{
+		"jsonrpc": "2.0", "id": 1, "method": "eth_getCode", "params": []string{addr, "latest"},
+	})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	var out struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("rpc error: %s", out.Error.Message)
+	}
+	code, err := hex.DecodeString(strings.TrimPrefix(out.Result, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid eth_getCode result: %w", err)
+	}
+	return code, nil
+}
+
+func report(w io.Writer, format string, checks []check) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(checks)
+	case "text":
+		failed := 0
+		for _, c := range checks {
+			if c.Status != statusOK && c.Status != statusUnset {
+				failed++
+			}
+			fmt.Fprintf(w, "%-13s %-24s %-22s %s", c.Status, c.Target, c.Name, orDash(c.Address))
+			switch {
+			case c.Error != "":
+				fmt.Fprintf(w, " (%s)", c.Error)
+			case c.Status == statusMismatch:
+				fmt.Fprintf(w, " (code hash %s, pinned %s)", c.CodeHash, c.Want)
+			case c.CodeSize > 0:
+				fmt.Fprintf(w, " (%d bytes)", c.CodeSize)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%d address(es), %d failing\n", len(checks), failed)
+		return nil
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func fatalf(format string, a ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", a...)
+	os.Exit(1)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	reg "github.com/compose-network/registry/registry"
+)
+
+const (
+	mailbox = "0x248721a59a2756E579026aDA017bd9B6adFe3e57"
+	factory = "0x54e692F4e290409035a9cC6A3d55eB047c82112C"
+	missing = "0x2498eF6bc1476652F5a47C50FAffBEa39Abbc4e5"
+	// keccak256(0x6000)
+	code6000Hash = "0x07ad118d6cc8642c86c03827f276d8b791a65e5c99a3845faf186be720a1455d"
+)
+
+// codeServer answers eth_getCode from code (lowercase address → hex code).
+func codeServer(t *testing.T, code map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params []string        `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_getCode" || len(req.Params) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		c, ok := code[strings.ToLower(req.Params[0])]
+		if !ok {
+			c = "0x"
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%q}`, req.ID, c)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVerify(t *testing.T) {
+	l1 := codeServer(t, map[string]string{strings.ToLower(factory): "0x6000"})
+	l2 := codeServer(t, map[string]string{strings.ToLower(mailbox): "0x6001"})
+
+	files := fstest.MapFS{
+		"networks/testnet/compose.toml": {Data: []byte(fmt.Sprintf(`[l1]
+chain_id = 1
+public_rpc = %q
+
+[publisher]
+superblock_contract = "0x0000000000000000000000000000000000000001"
+dispute_game_factory = %q
+`, l1.URL, factory))},
+		"networks/testnet/rollup.toml": {Data: []byte(fmt.Sprintf(`chain_id = 2
+public_rpc = %q
+
+[addresses]
+Mailbox = %q
+Bridge = %q
+Unset = ""
+`, l2.URL, mailbox, missing))},
+	}
+	r, err := reg.NewFromFS(files)
+	if err != nil {
+		t.Fatalf("NewFromFS() error: %v", err)
+	}
+	checks, err := collect(r, "")
+	if err != nil {
+		t.Fatalf("collect() error: %v", err)
+	}
+	p := pins{
+		"dispute_game_factory": code6000Hash,
+		"testnet/rollup":       map[string]any{"Mailbox": code6000Hash},
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	got := make(map[string]check)
+	for i := range checks {
+		verify(context.Background(), client, &checks[i], p)
+		got[checks[i].Target+" "+checks[i].Name] = checks[i]
+	}
+
+	want := map[string]string{
+		"testnet/l1 superblock_contract":  statusPlaceholder,
+		"testnet/l1 dispute_game_factory": statusOK,
+		"testnet/rollup Mailbox":          statusMismatch, // code 0x6001, pinned hash of 0x6000
+		"testnet/rollup Bridge":           statusNoCode,
+		"testnet/rollup Unset":            statusUnset,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d checks, want %d: %+v", len(got), len(want), checks)
+	}
+	for k, status := range want {
+		if got[k].Status != status {
+			t.Errorf("%s: status = %s, want %s (%+v)", k, got[k].Status, status, got[k])
+		}
+	}
+	if c := got["testnet/l1 dispute_game_factory"]; c.CodeHash != code6000Hash || c.CodeSize != 2 {
+		t.Errorf("factory code = %d bytes hash %s, want 2 bytes hash %s", c.CodeSize, c.CodeHash, code6000Hash)
+	}
+
+	var buf bytes.Buffer
+	if err := report(&buf, "text", checks); err != nil {
+		t.Fatalf("report() error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "5 address(es), 3 failing") {
+		t.Fatalf("text report summary:\n%s", buf.String())
+	}
+}
+
+func TestVerify_RPCError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"boom"}}`)
+	}))
+	defer srv.Close()
+	c := check{Target: "testnet/rollup", Name: "Mailbox", Address: mailbox, RPC: srv.URL}
+	verify(context.Background(), srv.Client(), &c, nil)
+	if c.Status != statusRPCError || !strings.Contains(c.Error, "boom") {
+		t.Fatalf("check = %+v, want rpc-error", c)
+	}
+}
@@ -0,0 +1,86 @@
+// Package toolutil holds helpers shared by the registry tools.
+package toolutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	reg "github.com/compose-network/registry/registry"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compress zstd-encodes raw with fixed settings and a single goroutine, so
+// the same input always produces the same bytes and regenerated genesis
+// blobs match the committed ones.
+func Compress(raw []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedBestCompression),
+		zstd.WithEncoderConcurrency(1),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = enc.Close() }()
+	return enc.EncodeAll(raw, nil), nil
+}
+
+// WriteFile writes b to path, creating its parent directories.
+func WriteFile(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("mkdir %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// PruneGenesis removes the genesis files at paths, given relative to
+// dataDir as Chain.GenesisPath returns them, that no chain under dataDir
+// references any more. Call it after repointing a chain at a new genesis so
+// the superseded file does not linger. It returns the paths it removed.
+func PruneGenesis(dataDir string, paths ...string) ([]string, error) {
+	r, err := reg.NewFromDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := r.ListChains()
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]bool, len(chains))
+	for _, c := range chains {
+		p, err := c.GenesisPath()
+		if err != nil {
+			return nil, err
+		}
+		referenced[p] = true
+	}
+	var removed []string
+	for _, p := range paths {
+		if referenced[p] || !strings.HasPrefix(p, "genesis/") {
+			continue
+		}
+		err := os.Remove(filepath.Join(dataDir, filepath.FromSlash(p)))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return removed, err
+		default:
+			removed = append(removed, p)
+		}
+	}
+	return removed, nil
+}
+
+// OrDefault returns s, or def when s is blank.
+func OrDefault(s, def string) string {
+	if strings.TrimSpace(s) == "" {
+		return def
+	}
+	return s
+}
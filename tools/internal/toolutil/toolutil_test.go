@@ -0,0 +1,48 @@
+package toolutil
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPruneGenesis(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"networks/net/compose.toml":                               "environment = \"testnet\"\n",
+		"networks/net/rollup-a.toml":                              "chain_id = 1\n[genesis]\nfile = \"sha256:" + strings.Repeat("a", 64) + "\"\n",
+		"networks/net/rollup-b.toml":                              "chain_id = 2\n[genesis]\nfile = \"sha256:" + strings.Repeat("a", 64) + "\"\n",
+		"networks/net/rollup-c.toml":                              "chain_id = 3\n[genesis]\nfile = \"sha256:" + strings.Repeat("c", 64) + "\"\n",
+		"genesis/sha256/" + strings.Repeat("a", 64) + ".json.zst": "shared",
+		"genesis/sha256/" + strings.Repeat("b", 64) + ".json.zst": "superseded",
+		"genesis/sha256/" + strings.Repeat("c", 64) + ".json.zst": "current",
+		"genesis/net/rollup-c.json.zst":                           "legacy",
+	}
+	for name, data := range files {
+		if err := WriteFile(filepath.Join(dir, filepath.FromSlash(name)), []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	removed, err := PruneGenesis(dir,
+		"genesis/sha256/"+strings.Repeat("a", 64)+".json.zst", // still used by rollup-b
+		"genesis/sha256/"+strings.Repeat("b", 64)+".json.zst",
+		"genesis/net/rollup-c.json.zst",
+		"genesis/sha256/"+strings.Repeat("d", 64)+".json.zst", // already gone
+		"networks/net/compose.toml",                           // not a genesis file
+	)
+	if err != nil {
+		t.Fatalf("PruneGenesis error: %v", err)
+	}
+	want := []string{"genesis/sha256/" + strings.Repeat("b", 64) + ".json.zst", "genesis/net/rollup-c.json.zst"}
+	if strings.Join(removed, ",") != strings.Join(want, ",") {
+		t.Fatalf("PruneGenesis removed %v, want %v", removed, want)
+	}
+	for name := range files {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		gone := name == want[0] || name == want[1]
+		if gone != os.IsNotExist(err) {
+			t.Errorf("%s: stat error = %v, want removed = %v", name, err, gone)
+		}
+	}
+}